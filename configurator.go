@@ -6,12 +6,15 @@ import (
 	"reflect"
 	"sort"
 	"strconv"
+	"strings"
 
 	"github.com/upfluence/errors"
 
 	"github.com/upfluence/cfg/internal/help"
+	"github.com/upfluence/cfg/internal/redact"
 	"github.com/upfluence/cfg/internal/reflectutil"
 	"github.com/upfluence/cfg/internal/setter"
+	"github.com/upfluence/cfg/internal/validator"
 	"github.com/upfluence/cfg/internal/walker"
 	"github.com/upfluence/cfg/provider"
 	dflt "github.com/upfluence/cfg/provider/default"
@@ -31,6 +34,16 @@ func IgnoreMissingTag(c *configurator) { c.ignoreMissingTag = true }
 
 func HonorRequired(c *configurator) { c.honorRequired = true }
 
+// CollectErrors makes Populate keep walking the struct when a field can
+// not be populated and return a *MultiError holding every failure.
+func CollectErrors(c *configurator) { c.collectErrors = true }
+
+// Interpolate expands the ${VAR} and ${VAR:-default} references of the
+// provided values before setting them, $$ standing for a literal $.
+// Variables are looked up as keys of the providers and then in the
+// process environment.
+func Interpolate(c *configurator) { c.interpolate = true }
+
 func WithProviders(ps ...provider.Provider) Option {
 	return func(c *configurator) { c.providers = append(c.providers, ps...) }
 }
@@ -44,8 +57,15 @@ func OverrideProviders(ps ...provider.Provider) Option {
 type configurator struct {
 	providers        []provider.Provider
 	factory          setter.Factory
+	parserOpts       []setter.FactoryOption
 	ignoreMissingTag bool
 	honorRequired    bool
+	collectErrors    bool
+	interpolate      bool
+
+	tracker         *KeyTracker
+	unusedKeysFn    UnusedKeysFunc
+	deprecatedKeyFn DeprecatedKeyFunc
 }
 
 func NewDefaultConfigurator(providers ...provider.Provider) Configurator {
@@ -103,43 +123,133 @@ func (c *configurator) WithOptions(opts ...Option) Configurator {
 	return c.withOptions(opts)
 }
 
+// populateState holds what a single Populate call accumulates while
+// walking the struct, nested map and slice elements included.
+type populateState struct {
+	report *Report
+
+	// keys records the keys looked up, it is nil when neither the key
+	// tracking nor the unused keys check is enabled.
+	keys *KeyTracker
+}
+
 func (c *configurator) Populate(ctx context.Context, in interface{}) error {
-	return walker.Walk(
+	return c.populateRoot(ctx, in, &populateState{})
+}
+
+// populateRoot populates in, records the keys looked up in the KeyTracker
+// of the TrackKeys option and reports the keys of the providers left unread
+// when the ReportUnusedKeys option is set.  Both are built from the keys
+// recorded by the walk in st.
+func (c *configurator) populateRoot(ctx context.Context, in interface{}, st *populateState) error {
+	if c.tracker != nil || c.unusedKeysFn != nil {
+		st.keys = NewKeyTracker()
+	}
+
+	err := c.populate(ctx, in, st)
+
+	c.tracker.merge(st.keys)
+
+	if err != nil || c.unusedKeysFn == nil {
+		return err
+	}
+
+	uks, err := UnusedKeys(ctx, st.keys, c.providers...)
+
+	if err != nil || len(uks) == 0 {
+		return err
+	}
+
+	return c.unusedKeysFn(ctx, uks)
+}
+
+func (c *configurator) populate(ctx context.Context, in interface{}, st *populateState) error {
+	if !c.collectErrors {
+		return walker.Walk(
+			in,
+			func(f *walker.Field) error { return c.walkFunc(ctx, f, st) },
+		)
+	}
+
+	var me MultiError
+
+	if err := walker.Walk(
 		in,
-		func(f *walker.Field) error { return c.walkFunc(ctx, f) },
-	)
+		func(f *walker.Field) error {
+			switch err := c.walkFunc(ctx, f, st); err {
+			case nil, walker.SkipStruct:
+				return err
+			default:
+				me.append(err)
+			}
+
+			return walker.SkipStruct
+		},
+	); err != nil {
+		return err
+	}
+
+	if len(me.Errors) == 0 {
+		return nil
+	}
+
+	return &me
 }
 
-func (c *configurator) walkFunc(ctx context.Context, f *walker.Field) error {
+func (c *configurator) walkFunc(ctx context.Context, f *walker.Field, st *populateState) error {
 	s := c.factory.Build(f.Field.Type)
 
 	if s == nil {
 		if reflectutil.SubKeyMapElem(f.Field.Type) != nil {
-			return c.populateMapField(ctx, f)
+			return c.populateMapField(ctx, f, st)
 		}
 
 		if reflectutil.SubKeySliceElem(f.Field.Type) != nil {
-			return c.populateSliceField(ctx, f)
+			return c.populateSliceField(ctx, f, st)
 		}
 
 		return nil
 	}
 
-	var set bool
+	var (
+		set bool
 
-	for _, p := range c.providers {
+		lastKey      string
+		lastValue    string
+		lastProvider provider.Provider
+
+		sources []Source
+
+		fv     = reflectutil.IndirectedValue(f.Value).FieldByName(f.Field.Name)
+		secret = redact.IsSecret(f)
+		multi  = isMultiValued(f.Field.Type)
+	)
+
+	merge, err := fieldMergeStrategy(f.Field)
+
+	if err != nil {
+		return err
+	}
+
+	for _, p := range walker.SortProviders(c.providers, f) {
 		var (
 			v   string
+			vs  []string
 			ok  bool
+			i   int
 			k   string
 			err error
 
-			fqp           = provider.WrapFullyQualifiedProvider(p)
-			ignoreMissing = c.ignoreMissingTag
+			fqp = provider.WrapFullyQualifiedProvider(p)
+			ks  = walker.BuildFieldKeys(fqp, f, c.ignoreMissingTag)
+			dks = c.deprecatedKeys(p, fqp, f)
 		)
 
-		for _, k = range walker.BuildFieldKeys(fqp, f, ignoreMissing) {
-			v, ok, err = p.Provide(ctx, k)
+		st.keys.record(p.StructTag(), ks)
+		st.keys.record(p.StructTag(), dks)
+
+		for i, k = range append(ks[:len(ks):len(ks)], dks...) {
+			vs, ok, err = provideValues(ctx, p, k, multi)
 
 			if err != nil {
 				return errors.WithStack(
@@ -152,21 +262,40 @@ func (c *configurator) walkFunc(ctx context.Context, f *walker.Field) error {
 				)
 			}
 
-			if ok {
-				break
+			if !ok {
+				continue
 			}
+
+			if i >= len(ks) && c.deprecatedKeyFn != nil {
+				c.deprecatedKeyFn(
+					ctx,
+					DeprecatedKey{Key: k, Keys: ks, Field: f.Field, Provider: p},
+				)
+			}
+
+			break
 		}
 
 		if !ok {
 			continue
 		}
 
+		ms := mergeReplace
+
+		if set {
+			ms = merge
+		}
+
 		set = true
+		v = strings.Join(vs, ",")
+		lastKey, lastValue, lastProvider = k, v, p
+		sources = append(sources, newSource(p, k, v, secret))
+
+		if err := c.mergeValues(ctx, f, s, vs, fv, ms); err != nil {
+			if secret {
+				err, v = redact.Error(err), redact.String(v)
+			}
 
-		if err := s.Set(
-			v,
-			reflectutil.IndirectedValue(f.Value).FieldByName(f.Field.Name),
-		); err != nil {
 			return errors.WithStack(
 				&SettingError{
 					Err:      err,
@@ -179,15 +308,34 @@ func (c *configurator) walkFunc(ctx context.Context, f *walker.Field) error {
 		}
 	}
 
-	if !set && c.honorRequired && isRequired(f.Field) {
+	if st.report != nil {
+		st.report.add(f.Path(), sources, merge)
+	}
+
+	if !set && c.honorRequired && validator.IsRequired(f.Field) {
 		return &RequiredError{Field: f.Field}
 	}
 
-	if setter.IsUnmarshaler(f.Value.Type()) {
-		return walker.SkipStruct
+	if set {
+		if err := validator.Validate(c.factory, f.Field, fv); err != nil {
+			if secret {
+				err, lastValue = redact.Error(err), redact.String(lastValue)
+			}
+
+			return errors.WithStack(
+				&ValidationError{
+					Err:      err,
+					Key:      lastKey,
+					Value:    lastValue,
+					Field:    f.Field,
+					Provider: lastProvider,
+				},
+			)
+		}
 	}
 
-	return nil
+	// The fields of a struct set as a whole are not read on their own.
+	return walker.SkipStruct
 }
 
 func (c *configurator) collectSubKeys(ctx context.Context, f *walker.Field) ([]string, error) {
@@ -226,7 +374,7 @@ func (c *configurator) collectSubKeys(ctx context.Context, f *walker.Field) ([]s
 	return keys, nil
 }
 
-func (c *configurator) populateMapField(ctx context.Context, f *walker.Field) error {
+func (c *configurator) populateMapField(ctx context.Context, f *walker.Field, st *populateState) error {
 	ft := reflectutil.IndirectedType(f.Field.Type)
 	elemIsPtr := ft.Elem().Kind() == reflect.Ptr
 	structType := reflectutil.SubKeyMapElem(f.Field.Type)
@@ -253,7 +401,7 @@ func (c *configurator) populateMapField(ctx context.Context, f *walker.Field) er
 			Value:    elem.Interface(),
 		}
 
-		if err := c.Populate(ctx, prefixed); err != nil {
+		if err := c.populate(ctx, prefixed, st); err != nil {
 			return err
 		}
 
@@ -275,7 +423,7 @@ func (c *configurator) populateMapField(ctx context.Context, f *walker.Field) er
 	return walker.SkipStruct
 }
 
-func (c *configurator) populateSliceField(ctx context.Context, f *walker.Field) error {
+func (c *configurator) populateSliceField(ctx context.Context, f *walker.Field, st *populateState) error {
 	ft := reflectutil.IndirectedType(f.Field.Type)
 	elemIsPtr := ft.Elem().Kind() == reflect.Ptr
 	structType := reflectutil.SubKeySliceElem(f.Field.Type)
@@ -323,7 +471,7 @@ func (c *configurator) populateSliceField(ctx context.Context, f *walker.Field)
 			Value:    elem.Interface(),
 		}
 
-		if err := c.Populate(ctx, prefixed); err != nil {
+		if err := c.populate(ctx, prefixed, st); err != nil {
 			return err
 		}
 
@@ -338,15 +486,3 @@ func (c *configurator) populateSliceField(ctx context.Context, f *walker.Field)
 
 	return walker.SkipStruct
 }
-
-func isRequired(f reflect.StructField) bool {
-	v, ok := f.Tag.Lookup("required")
-
-	if !ok {
-		return false
-	}
-
-	b, err := setter.ParseBool(v)
-
-	return err == nil && b
-}
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net"
+	"net/url"
 	"os"
 	"reflect"
 	"strings"
@@ -473,6 +474,22 @@ func TestConfigurator(t *testing.T) {
 			),
 			errAssertion: noError,
 		},
+		testCase{
+			caseName: "time.Time-rfc3339",
+			input:    &timeStruct{},
+			provider: &mockProvider{
+				st: map[string]string{"t": "2019-01-01T01:00:00.123456789+02:00"},
+			},
+			dataAssertion: func(t *testing.T, y interface{}) {
+				assert.True(
+					t,
+					time.Date(2019, 1, 1, 1, 0, 0, 123456789, time.FixedZone("", 7200)).Equal(
+						y.(*timeStruct).T,
+					),
+				)
+			},
+			errAssertion: noError,
+		},
 		testCase{
 			caseName:      "basic-ptr",
 			input:         &basicStruct3{},
@@ -912,6 +929,192 @@ func TestHonorRequired(t *testing.T) {
 	}
 }
 
+type validatedConfig struct {
+	Port    int           `mock:"port" min:"1" max:"65535"`
+	Timeout time.Duration `mock:"timeout" max:"1m"`
+	Level   string        `mock:"level" oneof:"debug,info"`
+	Name    string        `mock:"name" pattern:"^[a-z]+$"`
+	Tags    []string      `mock:"tags" len:"2"`
+	Owner   string        `mock:"owner" nonempty:"true"`
+}
+
+func TestValidation(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		have    map[string]string
+		wantKey string
+	}{
+		{name: "no value provided"},
+		{
+			name: "valid values",
+			have: map[string]string{
+				"port":    "8080",
+				"timeout": "30s",
+				"level":   "info",
+				"name":    "app",
+				"tags":    "a,b",
+				"owner":   "me",
+			},
+		},
+		{name: "lower than min", have: map[string]string{"port": "0"}, wantKey: "port"},
+		{name: "greater than max", have: map[string]string{"port": "70000"}, wantKey: "port"},
+		{name: "duration rule", have: map[string]string{"timeout": "2m"}, wantKey: "timeout"},
+		{name: "not one of", have: map[string]string{"level": "warn"}, wantKey: "level"},
+		{name: "pattern mismatch", have: map[string]string{"name": "App"}, wantKey: "name"},
+		{name: "wrong length", have: map[string]string{"tags": "a"}, wantKey: "tags"},
+		{name: "empty value", have: map[string]string{"owner": ""}, wantKey: "owner"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var (
+				c validatedConfig
+				p = &mockProvider{st: tc.have}
+			)
+
+			err := NewConfigurator(p).Populate(context.Background(), &c)
+
+			if tc.wantKey == "" {
+				require.NoError(t, err)
+				return
+			}
+
+			var ve *ValidationError
+
+			require.ErrorAs(t, err, &ve)
+			assert.Equal(t, tc.wantKey, ve.Key)
+			assert.Equal(t, tc.have[tc.wantKey], ve.Value)
+			assert.Equal(t, p, ve.Provider)
+		})
+	}
+}
+
+func TestValidationMissingValue(t *testing.T) {
+	var c struct {
+		Owner string `mock:"owner" nonempty:"true"`
+		Port  int    `mock:"port" min:"1"`
+		Name  string `mock:"name" nonempty:"true" required:"true"`
+	}
+
+	p := &mockProvider{st: map[string]string{"name": "app"}}
+
+	// Only the required tag covers the fields no provider sets, their
+	// zero value is not checked against the validation rules.
+	require.NoError(t, NewConfigurator(p).Populate(context.Background(), &c))
+
+	err := NewConfiguratorWithOptions(
+		WithProviders(&mockProvider{st: map[string]string{}}),
+		HonorRequired,
+	).Populate(context.Background(), &c)
+
+	var re *RequiredError
+
+	require.ErrorAs(t, err, &re)
+	assert.Equal(t, "Name", re.Field.Name)
+}
+
+type collectErrorsInner struct {
+	Port int `mock:"port"`
+}
+
+type collectErrorsConfig struct {
+	Host    string                        `mock:"host" required:"true"`
+	User    string                        `mock:"user" required:"true"`
+	Port    int                           `mock:"port"`
+	Level   string                        `mock:"level" oneof:"debug,info"`
+	Workers map[string]collectErrorsInner `mock:"workers"`
+}
+
+func TestCollectErrors(t *testing.T) {
+	var (
+		c collectErrorsConfig
+
+		p = &mockProvider{
+			st: map[string]string{
+				"port":           "not-a-number",
+				"level":          "warn",
+				"workers.a.port": "nan",
+			},
+		}
+	)
+
+	err := NewConfiguratorWithOptions(
+		WithProviders(p),
+		HonorRequired,
+		CollectErrors,
+	).Populate(context.Background(), &c)
+
+	var me *MultiError
+
+	require.ErrorAs(t, err, &me)
+	assert.Len(t, me.Errors, 5)
+
+	var (
+		re *RequiredError
+		se *SettingError
+		ve *ValidationError
+	)
+
+	require.ErrorAs(t, err, &re)
+	assert.Equal(t, "Host", re.Field.Name)
+
+	require.ErrorAs(t, err, &se)
+	assert.Equal(t, "port", se.Key)
+
+	require.ErrorAs(t, err, &ve)
+	assert.Equal(t, "level", ve.Key)
+
+	require.ErrorAs(t, me.Errors[4], &se)
+	assert.Equal(t, "workers.a.port", se.Key)
+
+	err = NewConfiguratorWithOptions(WithProviders(p), CollectErrors).Populate(
+		context.Background(),
+		&struct {
+			Host string `mock:"host"`
+		}{},
+	)
+
+	assert.NoError(t, err)
+}
+
+type secretConfig struct {
+	Token string `mock:"token" secret:"true" pattern:"^[a-z]+$"`
+	DB    struct {
+		Port int `mock:"port"`
+	} `mock:"db" secret:"true"`
+}
+
+func TestSecret(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		have    map[string]string
+		wantErr interface{}
+	}{
+		{name: "setting error", have: map[string]string{"db.port": "hunter2"}, wantErr: new(*SettingError)},
+		{name: "validation error", have: map[string]string{"token": "hunter2!"}, wantErr: new(*ValidationError)},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := NewConfigurator(&mockProvider{st: tc.have}).Populate(
+				context.Background(),
+				&secretConfig{},
+			)
+
+			require.Error(t, err)
+			assert.NotContains(t, err.Error(), "hunter2")
+			assert.Contains(t, err.Error(), "<redacted>")
+			assert.ErrorAs(t, err, tc.wantErr)
+		})
+	}
+
+	var c secretConfig
+
+	err := NewConfigurator(
+		&mockProvider{st: map[string]string{"token": "hunter", "db.port": "1"}},
+	).Populate(context.Background(), &c)
+
+	require.NoError(t, err)
+	assert.Equal(t, "hunter", c.Token)
+	assert.Equal(t, 1, c.DB.Port)
+}
+
 type prefixedConfig struct {
 	prefix []string
 	value  any
@@ -1046,3 +1249,35 @@ func ExampleNewDefaultConfigurator() {
 	// Output:
 	// bar
 }
+
+type settableStructConfig struct {
+	Endpoint url.URL    `mock:"endpoint"`
+	Network  *net.IPNet `mock:"network"`
+}
+
+func TestSettableStructFieldsAreNotWalked(t *testing.T) {
+	var (
+		c settableStructConfig
+
+		p = &mockProvider{
+			st: map[string]string{
+				"endpoint":      "https://a.com/x",
+				"endpoint.Host": "evil",
+				"network":       "10.0.0.0/8",
+				"network.IP":    "1.2.3.4",
+			},
+		}
+	)
+
+	r, err := PopulateWithReport(context.Background(), NewConfigurator(p), &c)
+
+	require.NoError(t, err)
+	assert.Equal(t, "https://a.com/x", c.Endpoint.String())
+	assert.Equal(t, "10.0.0.0/8", c.Network.String())
+	assert.Equal(
+		t,
+		[]string{"Endpoint", "Network"},
+		[]string{r.Fields[0].Path, r.Fields[1].Path},
+	)
+	assert.Len(t, r.Fields, 2)
+}
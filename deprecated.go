@@ -0,0 +1,78 @@
+package cfg
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+
+	"github.com/upfluence/cfg/internal/walker"
+	"github.com/upfluence/cfg/provider"
+	dflt "github.com/upfluence/cfg/provider/default"
+)
+
+// DeprecatedKey describes a field value read from one of the former keys
+// of the field, listed by its deprecated tag.
+type DeprecatedKey struct {
+	Key string
+
+	// Keys lists the keys to use instead.
+	Keys []string
+
+	Field    reflect.StructField
+	Provider provider.Provider
+}
+
+func (dk DeprecatedKey) String() string {
+	var (
+		k  = dk.Key
+		ks = append([]string(nil), dk.Keys...)
+	)
+
+	if kf, ok := dk.Provider.(provider.KeyFormatter); ok {
+		k = kf.FormatKey(k)
+
+		for i, k := range ks {
+			ks[i] = kf.FormatKey(k)
+		}
+	}
+
+	return fmt.Sprintf(
+		"%s: key %s of %s.%s is deprecated, use %s instead",
+		dk.Provider.StructTag(),
+		k,
+		dk.Field.Type.Name(),
+		dk.Field.Name,
+		strings.Join(ks, " or "),
+	)
+}
+
+// DeprecatedKeyFunc is called by Populate every time a field is read from
+// one of its deprecated keys.
+type DeprecatedKeyFunc func(context.Context, DeprecatedKey)
+
+// OnDeprecatedKey sets the function warned when a field is read from one
+// of the former names listed by its deprecated tag, e.g.
+// `env:"DATABASE_URL" deprecated:"DB_URL"`.  The deprecated keys are
+// looked up after the keys of the field, for each provider.
+func OnDeprecatedKey(fn DeprecatedKeyFunc) Option {
+	return func(c *configurator) { c.deprecatedKeyFn = fn }
+}
+
+// WriteDeprecatedKey returns a DeprecatedKeyFunc writing a warning line
+// to w.
+func WriteDeprecatedKey(w io.Writer) DeprecatedKeyFunc {
+	return func(_ context.Context, dk DeprecatedKey) {
+		fmt.Fprintf(w, "warning: %s\n", dk)
+	}
+}
+
+func (c *configurator) deprecatedKeys(p provider.Provider, fqp provider.FullyQualifiedProvider, f *walker.Field) []string {
+	// The keys of the default provider are the default values.
+	if _, ok := p.(dflt.Provider); ok {
+		return nil
+	}
+
+	return walker.BuildDeprecatedFieldKeys(fqp, f, c.ignoreMissingTag)
+}
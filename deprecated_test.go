@@ -0,0 +1,102 @@
+package cfg
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/upfluence/cfg/provider"
+	dflt "github.com/upfluence/cfg/provider/default"
+	"github.com/upfluence/cfg/provider/flags"
+)
+
+type deprecatedDBConfig struct {
+	URL string `env:"URL" flag:"url" deprecated:"ADDR,flag=addr"`
+}
+
+type deprecatedConfig struct {
+	DatabaseURL string             `env:"DATABASE_URL" flag:"database-url" default:"localhost" deprecated:"env=DB_URL"`
+	DB          deprecatedDBConfig `env:"DB" flag:"db"`
+}
+
+func TestOnDeprecatedKey(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		ps   []provider.Provider
+
+		want     deprecatedConfig
+		wantKeys []string
+	}{
+		{
+			name: "current keys",
+			ps: []provider.Provider{
+				provider.NewStaticProvider("env", map[string]string{"DATABASE_URL": "new", "DB_URL": "old"}, nil),
+			},
+			want: deprecatedConfig{DatabaseURL: "new"},
+		},
+		{
+			name: "default value",
+			ps:   []provider.Provider{dflt.Provider{}},
+			want: deprecatedConfig{DatabaseURL: "localhost"},
+		},
+		{
+			name: "deprecated keys",
+			ps: []provider.Provider{
+				provider.NewStaticProvider("env", map[string]string{"DB_URL": "old", "DB.ADDR": "addr"}, nil),
+				flags.NewProvider([]string{"--db.addr", "flag"}),
+			},
+			want: deprecatedConfig{
+				DatabaseURL: "old",
+				DB:          deprecatedDBConfig{URL: "flag"},
+			},
+			wantKeys: []string{
+				"env: key DB_URL of string.DatabaseURL is deprecated, use DATABASE_URL instead",
+				"env: key DB.ADDR of string.URL is deprecated, use DB.URL instead",
+				"flag: key --db.addr of string.URL is deprecated, use --db.url instead",
+			},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				c    deprecatedConfig
+				keys []string
+			)
+
+			err := NewConfigurator(tt.ps...).WithOptions(
+				OnDeprecatedKey(func(_ context.Context, dk DeprecatedKey) {
+					keys = append(keys, dk.String())
+				}),
+			).Populate(context.Background(), &c)
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, c)
+			assert.Equal(t, tt.wantKeys, keys)
+		})
+	}
+}
+
+func TestWriteDeprecatedKey(t *testing.T) {
+	var (
+		b  bytes.Buffer
+		kt = NewKeyTracker()
+		c  deprecatedConfig
+	)
+
+	err := NewConfigurator(
+		provider.NewStaticProvider("env", map[string]string{"DB_URL": "old"}, nil),
+	).WithOptions(
+		OnDeprecatedKey(WriteDeprecatedKey(&b)),
+		TrackKeys(kt),
+	).Populate(context.Background(), &c)
+
+	require.NoError(t, err)
+	assert.Equal(
+		t,
+		"warning: env: key DB_URL of string.DatabaseURL is deprecated, use DATABASE_URL instead\n",
+		b.String(),
+	)
+	assert.True(t, kt.Contains("env", "DB_URL"))
+}
@@ -3,6 +3,7 @@ package cfg
 import (
 	"fmt"
 	"reflect"
+	"strings"
 
 	"github.com/upfluence/cfg/provider"
 )
@@ -62,3 +63,69 @@ func (se *SettingError) Error() string {
 		se.Err.Error(),
 	)
 }
+
+// ValidationError is returned when the value set for a field does not
+// satisfy one of the constraints declared by its tags (min, max, len,
+// oneof, pattern, nonempty).  Key, Value and Provider describe the last
+// provider that set the field.
+//
+// The fields no provider sets are not validated, even against nonempty or
+// min: only the required tag, with the HonorRequired option, covers a
+// missing value.
+type ValidationError struct {
+	Err error
+
+	Value    string
+	Key      string
+	Field    reflect.StructField
+	Provider provider.Provider
+}
+
+func (ve *ValidationError) Unwrap() error { return ve.Err }
+
+func (ve *ValidationError) Error() string {
+	return fmt.Sprintf(
+		"invalid value for %s.%s(%q, %q, %q): %s",
+		ve.Field.Type.Name(),
+		ve.Field.Name,
+		ve.Key,
+		ve.Provider.StructTag(),
+		ve.Value,
+		ve.Err.Error(),
+	)
+}
+
+// MultiError is returned by Populate when the CollectErrors option is set.
+// It holds the error of every field that could not be populated, each of
+// them being reachable with errors.As.
+type MultiError struct {
+	Errors []error
+}
+
+func (me *MultiError) append(err error) {
+	if nested, ok := err.(*MultiError); ok {
+		me.Errors = append(me.Errors, nested.Errors...)
+		return
+	}
+
+	me.Errors = append(me.Errors, err)
+}
+
+func (me *MultiError) Unwrap() []error { return me.Errors }
+
+func (me *MultiError) Error() string {
+	if len(me.Errors) == 1 {
+		return me.Errors[0].Error()
+	}
+
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "%d fields could not be populated:", len(me.Errors))
+
+	for _, err := range me.Errors {
+		b.WriteString("\n\t- ")
+		b.WriteString(err.Error())
+	}
+
+	return b.String()
+}
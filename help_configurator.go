@@ -30,10 +30,12 @@ func (hc *helpConfigurator) WithOptions(opts ...Option) Configurator {
 	return &dup
 }
 
-func (hc *helpConfigurator) Populate(ctx context.Context, in interface{}) error {
+func (hc *helpConfigurator) handleHelp(ctx context.Context, in interface{}) error {
 	var cfg helpConfig
 
-	if err := hc.configurator.Populate(ctx, &cfg); err != nil {
+	// The keys of the providers are checked against the struct of the
+	// user, the help flag is not a configuration of its own.
+	if err := hc.configurator.populate(ctx, &cfg, &populateState{}); err != nil {
 		return err
 	}
 
@@ -43,9 +45,25 @@ func (hc *helpConfigurator) Populate(ctx context.Context, in interface{}) error
 		os.Exit(2)
 	}
 
+	return nil
+}
+
+func (hc *helpConfigurator) Populate(ctx context.Context, in interface{}) error {
+	if err := hc.handleHelp(ctx, in); err != nil {
+		return err
+	}
+
 	return hc.configurator.Populate(ctx, in)
 }
 
+func (hc *helpConfigurator) PopulateWithReport(ctx context.Context, in interface{}) (*Report, error) {
+	if err := hc.handleHelp(ctx, in); err != nil {
+		return nil, err
+	}
+
+	return hc.configurator.PopulateWithReport(ctx, in)
+}
+
 func (hc *helpConfigurator) PrintDefaults(in interface{}) error {
 	var _, err = hc.hw.Write(hc.stderr, in)
 	return err
@@ -0,0 +1,90 @@
+package completion
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/upfluence/errors"
+
+	"github.com/upfluence/cfg/internal/setter"
+	"github.com/upfluence/cfg/internal/walker"
+	"github.com/upfluence/cfg/provider"
+	"github.com/upfluence/cfg/provider/flags"
+)
+
+var DefaultFlagCollector = &FlagCollector{
+	Factory:  setter.DefaultFactory,
+	Provider: flags.NewProvider(nil),
+}
+
+// FlagCollector lists the flags populating a set of structs, formatted
+// with the key rules of the provider.
+type FlagCollector struct {
+	Factory          setter.Factory
+	Provider         provider.Provider
+	IgnoreMissingTag bool
+}
+
+func (fc *FlagCollector) Collect(ins ...interface{}) ([]string, error) {
+	var (
+		res  []string
+		seen = make(map[string]struct{})
+
+		fqp = provider.WrapFullyQualifiedProvider(fc.Provider)
+	)
+
+	kf, hasFormatter := fc.Provider.(provider.KeyFormatter)
+
+	var walkFn walker.WalkFunc
+
+	walkFn = func(f *walker.Field) error {
+		if s := fc.Factory.Build(f.Field.Type); s == nil {
+			prefixed := walker.BuildSubKeyField(f)
+
+			if prefixed == nil {
+				return nil
+			}
+
+			return errors.Wrap(walker.Walk(prefixed, walkFn), "walk")
+		}
+
+		for _, k := range walker.BuildFieldKeys(fqp, f, fc.IgnoreMissingTag) {
+			if hasFormatter {
+				k = kf.FormatKey(k)
+			}
+
+			if _, ok := seen[k]; ok {
+				continue
+			}
+
+			seen[k] = struct{}{}
+			res = append(res, k)
+		}
+
+		return walker.SkipStruct
+	}
+
+	for _, in := range ins {
+		if err := walker.Walk(in, walkFn); err != nil {
+			return nil, errors.Wrap(err, "walk")
+		}
+	}
+
+	return res, nil
+}
+
+// Filter returns the sorted candidates starting with prefix. The
+// candidates holding the placeholders of map and slice keys are dropped.
+func Filter(candidates []string, prefix string) []string {
+	var res []string
+
+	for _, c := range candidates {
+		if strings.HasPrefix(c, prefix) && !strings.Contains(c, "<") {
+			res = append(res, c)
+		}
+	}
+
+	sort.Strings(res)
+
+	return res
+}
@@ -0,0 +1,70 @@
+package completion
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type dbConfig struct {
+	Host string `flag:"host"`
+}
+
+type config struct {
+	Namespace string `flag:"n,namespace"`
+	Verbose   bool
+	Ignored   string `flag:"-"`
+
+	DB        dbConfig            `flag:"db"`
+	Databases map[string]dbConfig `flag:"databases"`
+}
+
+type otherConfig struct {
+	Namespace string `flag:"namespace"`
+	Output    string `flag:"o,output"`
+}
+
+func TestCollect(t *testing.T) {
+	fs, err := DefaultFlagCollector.Collect(&config{}, &otherConfig{})
+
+	assert.NoError(t, err)
+	assert.Equal(
+		t,
+		[]string{
+			"-n",
+			"--namespace",
+			"--verbose",
+			"--db.host",
+			"--databases.<key>.host",
+			"-o",
+			"--output",
+		},
+		fs,
+	)
+}
+
+func TestFilter(t *testing.T) {
+	for _, tt := range []struct {
+		name   string
+		in     []string
+		prefix string
+		out    []string
+	}{
+		{name: "empty"},
+		{
+			name: "no prefix",
+			in:   []string{"foo", "bar", "<key>"},
+			out:  []string{"bar", "foo"},
+		},
+		{
+			name:   "prefix",
+			in:     []string{"--namespace", "--name", "-n", "--db.<key>.host"},
+			prefix: "--na",
+			out:    []string{"--name", "--namespace"},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.out, Filter(tt.in, tt.prefix))
+		})
+	}
+}
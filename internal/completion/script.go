@@ -0,0 +1,85 @@
+package completion
+
+import (
+	"io"
+	"path/filepath"
+	"regexp"
+	"text/template"
+
+	"github.com/upfluence/errors"
+)
+
+const (
+	Bash = "bash"
+	Zsh  = "zsh"
+	Fish = "fish"
+)
+
+var (
+	Shells = []string{Bash, Zsh, Fish}
+
+	ErrUnknownShell = errors.New("unknown shell")
+
+	identifierRegexp = regexp.MustCompile(`[^a-zA-Z0-9_]`)
+
+	scripts = map[string]*template.Template{
+		Bash: template.Must(template.New(Bash).Parse(`# bash completion for {{.Name}}
+
+_{{.Function}}_completion() {
+	local cur="${COMP_WORDS[COMP_CWORD]}"
+	local IFS=$'\n'
+
+	COMPREPLY=($({{.Name}} {{.Command}} "--current=${cur}" -- "${COMP_WORDS[@]:1:COMP_CWORD-1}" 2>/dev/null))
+}
+
+complete -o default -F _{{.Function}}_completion {{.Name}}
+`)),
+		Zsh: template.Must(template.New(Zsh).Parse(`#compdef {{.Name}}
+
+_{{.Function}}() {
+	local -a completions
+
+	completions=("${(@f)$({{.Name}} {{.Command}} "--current=${words[CURRENT]}" -- "${(@)words[2,CURRENT-1]}" 2>/dev/null)}")
+
+	compadd -a completions
+}
+
+compdef _{{.Function}} {{.Name}}
+`)),
+		Fish: template.Must(template.New(Fish).Parse(`# fish completion for {{.Name}}
+
+function __{{.Function}}_complete
+	set -l tokens (commandline -opc)
+
+	{{.Name}} {{.Command}} "--current="(commandline -ct) -- $tokens[2..-1] 2>/dev/null
+end
+
+complete -c {{.Name}} -f -a '(__{{.Function}}_complete)'
+`)),
+	}
+)
+
+// Script describes the completion script of an app. The script calls the
+// Command sub command of the app with the flag --current set to the word
+// being completed, followed by "--" and the words preceding it. The sub
+// command is expected to print the candidates, one per line.
+type Script struct {
+	Name    string
+	Command string
+}
+
+func (s Script) Function() string {
+	return identifierRegexp.ReplaceAllString(s.Name, "_")
+}
+
+func (s Script) Write(w io.Writer, shell string) error {
+	t, ok := scripts[shell]
+
+	if !ok {
+		return errors.Wrapf(ErrUnknownShell, "%q", shell)
+	}
+
+	s.Name = filepath.Base(s.Name)
+
+	return errors.Wrap(t.Execute(w, s), "execute template")
+}
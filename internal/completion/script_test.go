@@ -0,0 +1,54 @@
+package completion
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScriptWrite(t *testing.T) {
+	s := Script{Name: "./bin/my-app", Command: "__complete"}
+
+	for _, tt := range []struct {
+		shell    string
+		contains []string
+	}{
+		{
+			shell: Bash,
+			contains: []string{
+				"_my_app_completion() {",
+				`my-app __complete "--current=${cur}" --`,
+				"complete -o default -F _my_app_completion my-app\n",
+			},
+		},
+		{
+			shell: Zsh,
+			contains: []string{
+				"#compdef my-app\n",
+				`my-app __complete "--current=${words[CURRENT]}" --`,
+				"compdef _my_app my-app\n",
+			},
+		},
+		{
+			shell: Fish,
+			contains: []string{
+				"function __my_app_complete\n",
+				`my-app __complete "--current="(commandline -ct) --`,
+				"complete -c my-app -f -a '(__my_app_complete)'\n",
+			},
+		},
+	} {
+		t.Run(tt.shell, func(t *testing.T) {
+			var b bytes.Buffer
+
+			assert.NoError(t, s.Write(&b, tt.shell))
+
+			for _, c := range tt.contains {
+				assert.Contains(t, b.String(), c)
+			}
+		})
+	}
+
+	assert.ErrorIs(t, s.Write(&bytes.Buffer{}, "tcsh"), ErrUnknownShell)
+}
@@ -9,8 +9,10 @@ import (
 
 	"github.com/upfluence/errors"
 
+	"github.com/upfluence/cfg/internal/redact"
 	"github.com/upfluence/cfg/internal/reflectutil"
 	"github.com/upfluence/cfg/internal/setter"
+	"github.com/upfluence/cfg/internal/validator"
 	"github.com/upfluence/cfg/internal/walker"
 	"github.com/upfluence/cfg/provider"
 	dflt "github.com/upfluence/cfg/provider/default"
@@ -37,7 +39,9 @@ type helper interface {
 	Help() string
 }
 
-func fieldHelp(f *walker.Field) string {
+// FieldHelp returns the help message of the field, provided by its Help
+// method or, failing that, by its help tag.
+func FieldHelp(f *walker.Field) string {
 	fv := reflectutil.IndirectedValue(f.Value).FieldByName(f.Field.Name)
 
 	if fv.CanAddr() && fv.Addr().Type().Implements(helperType) {
@@ -63,94 +67,165 @@ type Writer struct {
 	IgnoreMissingTag bool
 }
 
-func (w *Writer) writeConfig(out io.Writer, in interface{}) (int, error) {
-	var n int
+// Field describes a configuration field as listed by the Writer.
+type Field struct {
+	Name    string
+	Type    string
+	Help    string
+	Default string
+	Rules   []validator.Rule
+
+	// Keys lists, for each provider, the keys the field is read from,
+	// formatted with the key rules of the provider.
+	Keys []ProviderKeys
+}
+
+type ProviderKeys struct {
+	StructTag string
+	Keys      []string
 
-	return n, errors.Wrap(walker.Walk(in, w.buildWalkFn(out, &n, true)), "walk")
+	// Deprecated lists the former keys of the field, still read after
+	// Keys.
+	Deprecated []string
 }
 
-func (w *Writer) buildWalkFn(out io.Writer, n *int, includeDefaults bool) walker.WalkFunc {
-	return func(f *walker.Field) error {
-		s := w.Factory.Build(f.Field.Type)
+func (pk ProviderKeys) String() string {
+	ks := pk.Keys
 
-		if s == nil {
-			return w.writeSubKeyField(out, n, f)
-		}
+	for _, k := range pk.Deprecated {
+		ks = append(ks[:len(ks):len(ks)], k+" (deprecated)")
+	}
 
-		fks := walker.BuildFieldKeys(
-			provider.WrapFullyQualifiedProvider(
-				provider.NewStaticProvider("", nil, nil),
-			),
-			f,
-			w.IgnoreMissingTag,
-		)
+	return fmt.Sprintf("%s: %s", pk.StructTag, strings.Join(ks, ", "))
+}
 
-		if len(fks) == 0 {
-			return nil
-		}
+func (f Field) String() string {
+	var b strings.Builder
 
-		if setter.IsUnmarshaler(f.Value.Type()) {
-			return walker.SkipStruct
+	b.WriteString(f.Name)
+	b.WriteString(": ")
+	b.WriteString(f.Type)
+
+	if f.Help != "" {
+		b.WriteString(" ")
+		b.WriteString(f.Help)
+	}
+
+	if f.Default != "" {
+		b.WriteString(" (default: ")
+		b.WriteString(f.Default)
+		b.WriteString(")")
+	}
+
+	if len(f.Rules) > 0 {
+		rs := make([]string, len(f.Rules))
+
+		for i, r := range f.Rules {
+			rs[i] = r.String()
 		}
 
-		var b bytes.Buffer
+		b.WriteString(" (")
+		b.WriteString(strings.Join(rs, ", "))
+		b.WriteString(")")
+	}
 
-		b.WriteString("\t- ")
-		b.WriteString(fks[0])
-		b.WriteString(": ")
-		b.WriteString(s.String())
+	ks := make([]string, len(f.Keys))
 
-		if includeDefaults {
-			if h := fieldHelp(f); h != "" {
-				b.WriteString(" ")
-				b.WriteString(h)
-			}
+	for i, k := range f.Keys {
+		ks[i] = k.String()
+	}
+
+	b.WriteString(" (")
+	b.WriteString(strings.Join(ks, ", "))
+	b.WriteString(")")
+
+	return b.String()
+}
+
+// Fields returns the fields of the structs read by at least one of the
+// providers.
+func (w *Writer) Fields(ins ...interface{}) ([]Field, error) {
+	var fs []Field
+
+	for _, in := range ins {
+		if err := walker.Walk(in, w.buildWalkFn(&fs, true)); err != nil {
+			return nil, errors.Wrap(err, "walk")
 		}
+	}
 
-		var defaultValue string
+	return fs, nil
+}
 
-		providedKeys, tagDefault := w.providerKeys(f)
+func (w *Writer) buildWalkFn(fs *[]Field, includeDefaults bool) walker.WalkFunc {
+	return func(f *walker.Field) error {
+		s := w.Factory.Build(f.Field.Type)
 
-		if includeDefaults {
-			defaultValue = fieldDefault(f)
+		if s == nil {
+			return w.walkSubKeyField(fs, f)
+		}
 
-			if tagDefault != "" {
-				defaultValue = tagDefault
-			}
+		fks := walker.BuildFieldKeys(walker.NameProvider, f, w.IgnoreMissingTag)
+
+		if len(fks) == 0 {
+			return walker.SkipStruct
 		}
 
+		providedKeys, tagDefault := w.providerKeys(f)
+
 		if len(providedKeys) == 0 {
-			return nil
+			return walker.SkipStruct
 		}
 
-		if defaultValue != "" {
-			b.WriteString(" (default: ")
-			b.WriteString(defaultValue)
-			b.WriteString(")")
+		fd := Field{
+			Name:  fks[0],
+			Type:  s.String(),
+			Rules: validator.Rules(f.Field),
+			Keys:  providedKeys,
 		}
 
-		b.WriteString(" (")
-		b.WriteString(strings.Join(providedKeys, ", "))
-		b.WriteString(")")
+		if includeDefaults {
+			fd.Help = FieldHelp(f)
+			fd.Default = fieldDefault(f)
 
-		b.WriteRune('\n')
+			if tagDefault != "" {
+				fd.Default = tagDefault
+			}
+		}
 
-		nn, err := b.WriteTo(out)
+		if fd.Default != "" && redact.IsSecret(f) {
+			fd.Default = redact.Placeholder
+		}
 
-		*n += int(nn)
+		*fs = append(*fs, fd)
 
-		return errors.Wrap(err, "write")
+		return walker.SkipStruct
 	}
 }
 
-func (w *Writer) writeSubKeyField(out io.Writer, n *int, f *walker.Field) error {
+func (w *Writer) walkSubKeyField(fs *[]Field, f *walker.Field) error {
 	prefixed := walker.BuildSubKeyField(f)
 
 	if prefixed == nil {
 		return nil
 	}
 
-	return errors.Wrap(walker.Walk(prefixed, w.buildWalkFn(out, n, false)), "walk")
+	return errors.Wrap(walker.Walk(prefixed, w.buildWalkFn(fs, false)), "walk")
+}
+
+// FieldDefault returns the default value of the field, set by its default
+// tag or, failing that, the value the field already holds.
+func FieldDefault(f *walker.Field) string {
+	ks := walker.BuildFieldKeys(
+		provider.WrapFullyQualifiedProvider(dflt.Provider{}),
+		f,
+		false,
+	)
+
+	if len(ks) > 0 && ks[0] != "" {
+		return ks[0]
+	}
+
+	return fieldDefault(f)
 }
 
 func fieldDefault(f *walker.Field) string {
@@ -169,9 +244,9 @@ func fieldDefault(f *walker.Field) string {
 	return fmt.Sprintf("%+v", v)
 }
 
-func (w *Writer) providerKeys(f *walker.Field) ([]string, string) {
+func (w *Writer) providerKeys(f *walker.Field) ([]ProviderKeys, string) {
 	var (
-		providedKeys []string
+		providedKeys []ProviderKeys
 		tagDefault   string
 	)
 
@@ -194,15 +269,21 @@ func (w *Writer) providerKeys(f *walker.Field) ([]string, string) {
 			continue
 		}
 
+		dks := walker.BuildDeprecatedFieldKeys(fqp, f, w.IgnoreMissingTag)
+
 		if kf, ok := p.(provider.KeyFormatter); ok {
 			for i, k := range ks {
 				ks[i] = kf.FormatKey(k)
 			}
+
+			for i, k := range dks {
+				dks[i] = kf.FormatKey(k)
+			}
 		}
 
 		providedKeys = append(
 			providedKeys,
-			fmt.Sprintf("%s: %s", p.StructTag(), strings.Join(ks, ", ")),
+			ProviderKeys{StructTag: p.StructTag(), Keys: ks, Deprecated: dks},
 		)
 	}
 
@@ -210,20 +291,23 @@ func (w *Writer) providerKeys(f *walker.Field) ([]string, string) {
 }
 
 func (w *Writer) Write(out io.Writer, ins ...interface{}) (int, error) {
-	n, err := out.Write(defaultHeaders)
+	fs, err := w.Fields(ins...)
 
 	if err != nil {
-		return n, err
+		return 0, err
 	}
 
-	for _, in := range ins {
-		nn, err := w.writeConfig(out, in)
-		n += nn
+	var b bytes.Buffer
 
-		if err != nil {
-			return n, err
-		}
+	b.Write(defaultHeaders)
+
+	for _, f := range fs {
+		b.WriteString("\t- ")
+		b.WriteString(f.String())
+		b.WriteRune('\n')
 	}
 
-	return n, nil
+	n, err := b.WriteTo(out)
+
+	return int(n), errors.Wrap(err, "write")
 }
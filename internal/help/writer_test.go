@@ -2,7 +2,11 @@ package help
 
 import (
 	"bytes"
+	"io/fs"
+	"net"
+	"net/url"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -40,6 +44,15 @@ type dbConfig struct {
 	Port int    `default:"5432" env:"PORT" flag:"port"`
 }
 
+type constrainedConfig struct {
+	Port  int    `env:"PORT" min:"1" max:"65535"`
+	Level string `env:"LEVEL" oneof:"debug,info" default:"info"`
+}
+
+type secretConfig struct {
+	Password string `env:"PASSWORD" default:"changeme" secret:"true"`
+}
+
 type helpString string
 
 func (h helpString) Help() string { return string(h) }
@@ -68,6 +81,25 @@ type mapPtrStructConfig struct {
 	Databases map[string]*dbConfig `env:"DATABASES" flag:"databases"`
 }
 
+type stdlibConfig struct {
+	Endpoint *url.URL       `flag:"endpoint"`
+	Allowed  []net.IPNet    `flag:"allowed"`
+	Mode     fs.FileMode    `flag:"mode"`
+	Zone     *time.Location `flag:"zone"`
+}
+
+type deprecatedConfig struct {
+	DatabaseURL string `env:"DATABASE_URL" flag:"database-url" deprecated:"env=DB_URL,flag=db-url"`
+}
+
+type sourcesConfig struct {
+	Token    string `sources:"env"`
+	Port     int    `default:"8080" sources:"flag"`
+	Database struct {
+		URL string
+	} `sources:"env"`
+}
+
 func TestPrintDefaults(t *testing.T) {
 	for _, tt := range []struct {
 		name string
@@ -152,6 +184,42 @@ func TestPrintDefaults(t *testing.T) {
 				"\t- Databases.<key>.Host: string (env: DATABASES_<KEY>_HOST, flag: --databases.<key>.host)\n" +
 				"\t- Databases.<key>.Port: integer (env: DATABASES_<KEY>_PORT, flag: --databases.<key>.port)\n",
 		},
+		{
+			name: "validation rules",
+			in:   &constrainedConfig{},
+			out: "Arguments:\n" +
+				"\t- Port: integer (min: 1, max: 65535) (env: PORT, flag: --port)\n" +
+				"\t- Level: string (default: info) (oneof: debug,info) (env: LEVEL, flag: --level)\n",
+		},
+		{
+			name: "secret default",
+			in:   &secretConfig{},
+			out: "Arguments:\n" +
+				"\t- Password: string (default: <redacted>) (env: PASSWORD, flag: --password)\n",
+		},
+		{
+			name: "standard library types",
+			in:   &stdlibConfig{},
+			out: "Arguments:\n" +
+				"\t- Endpoint: url (env: ENDPOINT, flag: --endpoint)\n" +
+				"\t- Allowed: []cidr (env: ALLOWED, flag: --allowed)\n" +
+				"\t- Mode: filemode (env: MODE, flag: --mode)\n" +
+				"\t- Zone: location (env: ZONE, flag: --zone)\n",
+		},
+		{
+			name: "deprecated keys",
+			in:   &deprecatedConfig{},
+			out: "Arguments:\n" +
+				"\t- DatabaseURL: string (env: DATABASE_URL, DB_URL (deprecated), flag: --database-url, --db-url (deprecated))\n",
+		},
+		{
+			name: "sources",
+			in:   &sourcesConfig{},
+			out: "Arguments:\n" +
+				"\t- Token: string (env: TOKEN)\n" +
+				"\t- Port: integer (default: 8080) (flag: --port)\n" +
+				"\t- Database.URL: string (env: DATABASE_URL)\n",
+		},
 	} {
 		t.Run(tt.name, func(t *testing.T) {
 			var b bytes.Buffer
@@ -163,3 +231,20 @@ func TestPrintDefaults(t *testing.T) {
 		})
 	}
 }
+
+func TestFields(t *testing.T) {
+	fs, err := DefaultWriter.Fields(&constrainedConfig{})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(fs))
+	assert.Equal(t, "Level", fs[1].Name)
+	assert.Equal(t, "info", fs[1].Default)
+	assert.Equal(
+		t,
+		[]ProviderKeys{
+			{StructTag: "env", Keys: []string{"LEVEL"}},
+			{StructTag: "flag", Keys: []string{"--level"}},
+		},
+		fs[1].Keys,
+	)
+}
@@ -0,0 +1,127 @@
+package interpolate
+
+import (
+	"strings"
+
+	"github.com/upfluence/errors"
+)
+
+var (
+	ErrMalformed = errors.New("malformed reference")
+	ErrUndefined = errors.New("undefined variable")
+	ErrCycle     = errors.New("reference cycle")
+)
+
+// LookupFunc resolves the value of a referenced variable.
+type LookupFunc func(string) (string, bool, error)
+
+// Expand replaces the ${VAR} references of s by the value returned by fn.
+// ${VAR:-default} falls back to default when VAR is unset or empty and $$
+// stands for a literal $.  Resolved values are expanded as well, a
+// variable referencing itself, directly or not, returns ErrCycle.
+func Expand(s string, fn LookupFunc) (string, error) {
+	e := expander{lookup: fn}
+
+	return e.expand(s)
+}
+
+type expander struct {
+	lookup LookupFunc
+	stack  []string
+}
+
+func (e *expander) expand(s string) (string, error) {
+	if !strings.Contains(s, "$") {
+		return s, nil
+	}
+
+	var b strings.Builder
+
+	for i := 0; i < len(s); i++ {
+		if s[i] != '$' || i == len(s)-1 {
+			b.WriteByte(s[i])
+			continue
+		}
+
+		switch s[i+1] {
+		case '$':
+			b.WriteByte('$')
+			i++
+		case '{':
+			end := closingBrace(s, i+2)
+
+			if end < 0 {
+				return "", errors.Wrapf(ErrMalformed, "%q is not terminated", s[i:])
+			}
+
+			v, err := e.resolve(s[i+2 : end])
+
+			if err != nil {
+				return "", err
+			}
+
+			b.WriteString(v)
+			i = end
+		default:
+			b.WriteByte('$')
+		}
+	}
+
+	return b.String(), nil
+}
+
+func closingBrace(s string, start int) int {
+	depth := 1
+
+	for j := start; j < len(s); j++ {
+		switch {
+		case s[j] == '{' && s[j-1] == '$':
+			depth++
+		case s[j] == '}':
+			if depth--; depth == 0 {
+				return j
+			}
+		}
+	}
+
+	return -1
+}
+
+func (e *expander) resolve(ref string) (string, error) {
+	name, dflt, hasDefault := strings.Cut(ref, ":-")
+
+	if name == "" {
+		return "", errors.Wrapf(ErrMalformed, "%q has no variable name", "${"+ref+"}")
+	}
+
+	for i, n := range e.stack {
+		if n == name {
+			return "", errors.Wrapf(
+				ErrCycle,
+				"%s",
+				strings.Join(append(e.stack[i:], name), " -> "),
+			)
+		}
+	}
+
+	v, ok, err := e.lookup(name)
+
+	if err != nil {
+		return "", errors.Wrapf(err, "lookup %q", name)
+	}
+
+	if !ok || v == "" {
+		if hasDefault {
+			return e.expand(dflt)
+		}
+
+		if !ok {
+			return "", errors.Wrapf(ErrUndefined, "%q", name)
+		}
+	}
+
+	e.stack = append(e.stack, name)
+	defer func() { e.stack = e.stack[:len(e.stack)-1] }()
+
+	return e.expand(v)
+}
@@ -0,0 +1,66 @@
+package interpolate
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExpand(t *testing.T) {
+	var (
+		errLookup = errors.New("lookup failure")
+
+		vars = map[string]string{
+			"USER":   "admin",
+			"HOST":   "db",
+			"EMPTY":  "",
+			"URL":    "postgres://${USER}@${HOST}/app",
+			"SELF":   "${SELF}",
+			"A":      "${B}",
+			"B":      "x${A}",
+			"NESTED": "${URL}?sslmode=disable",
+		}
+
+		lookup = func(k string) (string, bool, error) {
+			if k == "FAIL" {
+				return "", false, errLookup
+			}
+
+			v, ok := vars[k]
+
+			return v, ok, nil
+		}
+	)
+
+	for _, tc := range []struct {
+		name    string
+		in      string
+		want    string
+		wantErr error
+	}{
+		{name: "no reference", in: "plain", want: "plain"},
+		{name: "references", in: "${USER}@${HOST}", want: "admin@db"},
+		{name: "nested references", in: "${NESTED}", want: "postgres://admin@db/app?sslmode=disable"},
+		{name: "escape", in: "$${USER} costs 5$", want: "${USER} costs 5$"},
+		{name: "lone dollar", in: "a$b", want: "a$b"},
+		{name: "default unset", in: "${PORT:-5432}", want: "5432"},
+		{name: "default empty", in: "${EMPTY:-x}", want: "x"},
+		{name: "default set", in: "${USER:-x}", want: "admin"},
+		{name: "default reference", in: "${PORT:-${HOST}}", want: "db"},
+		{name: "empty value", in: "[${EMPTY}]", want: "[]"},
+		{name: "undefined", in: "${PORT}", wantErr: ErrUndefined},
+		{name: "unterminated", in: "${USER", wantErr: ErrMalformed},
+		{name: "no name", in: "${:-x}", wantErr: ErrMalformed},
+		{name: "self cycle", in: "${SELF}", wantErr: ErrCycle},
+		{name: "indirect cycle", in: "${A}", wantErr: ErrCycle},
+		{name: "lookup error", in: "${FAIL}", wantErr: errLookup},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := Expand(tc.in, lookup)
+
+			assert.ErrorIs(t, err, tc.wantErr)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
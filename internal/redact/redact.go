@@ -0,0 +1,57 @@
+package redact
+
+import (
+	"github.com/upfluence/cfg/internal/setter"
+	"github.com/upfluence/cfg/internal/walker"
+)
+
+const (
+	Placeholder = "<redacted>"
+
+	tag = "secret"
+)
+
+// IsSecret reports whether the field, or one of its ancestors, is
+// flagged with a truthy `secret` tag.
+func IsSecret(f *walker.Field) bool {
+	for ; f != nil; f = f.Ancestor {
+		v, ok := f.Field.Tag.Lookup(tag)
+
+		if !ok {
+			continue
+		}
+
+		if b, err := setter.ParseBool(v); err == nil && b {
+			return true
+		}
+	}
+
+	return false
+}
+
+// String returns the placeholder in place of a non empty value.
+func String(v string) string {
+	if v == "" {
+		return ""
+	}
+
+	return Placeholder
+}
+
+type redactedError struct {
+	error
+}
+
+func (re redactedError) Error() string { return "invalid " + Placeholder + " value" }
+
+func (re redactedError) Unwrap() error { return re.error }
+
+// Error hides the message of an error that may quote a secret value,
+// the original error is still reachable with errors.Is and errors.As.
+func Error(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	return redactedError{error: err}
+}
@@ -0,0 +1,43 @@
+package redact
+
+import (
+	"errors"
+	"reflect"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/upfluence/cfg/internal/walker"
+)
+
+func TestIsSecret(t *testing.T) {
+	var (
+		plain  = &walker.Field{Field: reflect.StructField{Name: "Host"}}
+		secret = &walker.Field{Field: reflect.StructField{Name: "DB", Tag: `secret:"true"`}}
+		off    = &walker.Field{Field: reflect.StructField{Name: "Token", Tag: `secret:"false"`}}
+	)
+
+	assert.False(t, IsSecret(plain))
+	assert.True(t, IsSecret(secret))
+	assert.False(t, IsSecret(off))
+	assert.True(
+		t,
+		IsSecret(&walker.Field{Field: reflect.StructField{Name: "Password"}, Ancestor: secret}),
+	)
+}
+
+func TestString(t *testing.T) {
+	assert.Equal(t, "", String(""))
+	assert.Equal(t, Placeholder, String("hunter2"))
+}
+
+func TestError(t *testing.T) {
+	_, err := strconv.Atoi("hunter2")
+
+	rerr := Error(err)
+
+	assert.NotContains(t, rerr.Error(), "hunter2")
+	assert.True(t, errors.Is(rerr, strconv.ErrSyntax))
+	assert.Nil(t, Error(nil))
+}
@@ -0,0 +1,88 @@
+package setter
+
+import (
+	"io/fs"
+	"net"
+	"net/netip"
+	"net/url"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/upfluence/errors"
+)
+
+// builtinParsers are the parsers of the standard library and unit types,
+// named after the notation they accept.
+var builtinParsers = map[reflect.Type]customParserSpec{
+	reflect.TypeFor[url.URL]():        derefSpec("url", url.Parse),
+	reflect.TypeFor[net.IP]():         {name: "ip", fn: wrapParse(parseIP)},
+	reflect.TypeFor[net.IPNet]():      derefSpec("cidr", parseIPNet),
+	reflect.TypeFor[netip.Addr]():     {name: "ip", fn: wrapParse(netip.ParseAddr)},
+	reflect.TypeFor[netip.AddrPort](): {name: "ip:port", fn: wrapParse(netip.ParseAddrPort)},
+	reflect.TypeFor[netip.Prefix]():   {name: "cidr", fn: wrapParse(netip.ParsePrefix)},
+	reflect.TypeFor[regexp.Regexp]():  derefSpec("regexp", regexp.Compile),
+	reflect.TypeFor[time.Location]():  derefSpec("location", time.LoadLocation),
+	reflect.TypeFor[fs.FileMode]():    {name: "filemode", fn: wrapParse(parseFileMode)},
+
+	reflect.TypeFor[ByteSize](): {name: "bytesize", fn: wrapParse(ParseByteSize)},
+	reflect.TypeFor[Quantity](): {name: "quantity", fn: wrapParse(ParseQuantity)},
+	reflect.TypeFor[Rate]():     {name: "rate", fn: wrapParse(ParseRate)},
+	reflect.TypeFor[Percent]():  {name: "percent", fn: wrapParse(ParsePercent)},
+}
+
+func wrapParse[T any](fn func(string) (T, error)) ParseFunc {
+	return func(v string, _ reflect.Type) (interface{}, error) { return fn(v) }
+}
+
+func derefSpec[T any](name string, fn func(string) (*T, error)) customParserSpec {
+	return customParserSpec{name: name, fn: wrapParse(fn), deref: true}
+}
+
+func parseIP(v string) (net.IP, error) {
+	ip := net.ParseIP(v)
+
+	if ip == nil {
+		return nil, errors.Newf("%q is not a valid IP address", v)
+	}
+
+	return ip, nil
+}
+
+func parseIPNet(v string) (*net.IPNet, error) {
+	_, n, err := net.ParseCIDR(v)
+
+	return n, err
+}
+
+const fileModePerms = "rwxrwxrwx"
+
+// parseFileMode parses the octal permissions of a file, e.g. "0644" or
+// "0o644", or their symbolic form, e.g. "-rw-r--r--".
+func parseFileMode(v string) (fs.FileMode, error) {
+	if len(v) == len(fileModePerms)+1 && v[0] == '-' {
+		var m fs.FileMode
+
+		for i, c := range v[1:] {
+			switch c {
+			case rune(fileModePerms[i]):
+				m |= 1 << (len(fileModePerms) - 1 - i)
+			case '-':
+			default:
+				return 0, errors.Newf("%q is not a valid file mode", v)
+			}
+		}
+
+		return m, nil
+	}
+
+	m, err := strconv.ParseUint(strings.TrimPrefix(v, "0o"), 8, 32)
+
+	if err != nil {
+		return 0, errors.Wrapf(err, "%q is not a valid octal file mode", v)
+	}
+
+	return fs.FileMode(m), nil
+}
@@ -0,0 +1,133 @@
+package setter
+
+import (
+	"reflect"
+
+	"github.com/upfluence/errors"
+)
+
+// ParseFunc parses the value of a type registered on the factory.  The
+// returned value is converted to t, the type being set, when they differ.
+type ParseFunc func(v string, t reflect.Type) (interface{}, error)
+
+type kindParser struct {
+	kind  reflect.Kind
+	match func(reflect.Type) bool
+
+	name string
+	fn   ParseFunc
+}
+
+// WithTypeParser registers fn as the parser of the values of type t, and
+// of the pointers to it.  The name is the type displayed in the help
+// messages.
+func WithTypeParser(t reflect.Type, name string, fn ParseFunc) FactoryOption {
+	spec := customParserSpec{name: name, fn: fn}
+
+	// A parser of *T is registered as the parser of T, the pointer being
+	// dereferenced.
+	if t.Kind() == reflect.Ptr {
+		spec.deref = true
+		t = t.Elem()
+	}
+
+	return func(opts *factoryOptions) {
+		tps := make(map[reflect.Type]customParserSpec, len(opts.typeParsers)+1)
+
+		for k, v := range opts.typeParsers {
+			tps[k] = v
+		}
+
+		tps[t] = spec
+		opts.typeParsers = tps
+	}
+}
+
+// WithKindParser registers fn as the parser of the values of the types of
+// the given kind matched by match, a nil match matching every type of the
+// kind.  The parsers registered with WithTypeParser take precedence, then
+// the kind parsers are tried in their registration order.
+func WithKindParser(k reflect.Kind, match func(reflect.Type) bool, name string, fn ParseFunc) FactoryOption {
+	return func(opts *factoryOptions) {
+		opts.kindParsers = append(
+			opts.kindParsers[:len(opts.kindParsers):len(opts.kindParsers)],
+			kindParser{kind: k, match: match, name: name, fn: fn},
+		)
+	}
+}
+
+// WithParser registers fn as the parser of the values of type T, see
+// WithTypeParser.
+func WithParser[T any](name string, fn func(string) (T, error)) FactoryOption {
+	return WithTypeParser(
+		reflect.TypeFor[T](),
+		name,
+		func(v string, _ reflect.Type) (interface{}, error) { return fn(v) },
+	)
+}
+
+type customParserSpec struct {
+	name  string
+	fn    ParseFunc
+	deref bool
+}
+
+func (fo factoryOptions) customParser(t reflect.Type) parser {
+	if spec, ok := fo.typeParsers[t]; ok {
+		return &customParser{t: t, spec: spec}
+	}
+
+	for _, kp := range fo.kindParsers {
+		if t.Kind() == kp.kind && (kp.match == nil || kp.match(t)) {
+			return &customParser{t: t, spec: customParserSpec{name: kp.name, fn: kp.fn}}
+		}
+	}
+
+	return nil
+}
+
+type customParser struct {
+	t    reflect.Type
+	spec customParserSpec
+}
+
+func (cp *customParser) String() string { return cp.spec.name }
+
+func (cp *customParser) parse(value string, ptr bool) (interface{}, error) {
+	v, err := cp.spec.fn(value, cp.t)
+
+	if err != nil {
+		return nil, err
+	}
+
+	rv := reflect.ValueOf(v)
+
+	if cp.spec.deref && rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			return nil, errors.Newf("cfg: parser of %s returned a nil value", cp.t)
+		}
+
+		// The pointer returned by the parser is kept as is, e.g. so
+		// time.UTC is not copied.
+		if ptr && rv.Type().Elem() == cp.t {
+			return v, nil
+		}
+
+		rv = rv.Elem()
+	}
+
+	if !rv.IsValid() || !rv.Type().ConvertibleTo(cp.t) {
+		return nil, errors.Newf("cfg: parser of %s returned a %T value", cp.t, v)
+	}
+
+	rv = rv.Convert(cp.t)
+
+	if !ptr {
+		return rv.Interface(), nil
+	}
+
+	pv := reflect.New(cp.t)
+	pv.Elem().Set(rv)
+
+	return pv.Interface(), nil
+}
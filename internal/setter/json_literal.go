@@ -0,0 +1,60 @@
+package setter
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// decodeJSONArray decodes v when it is a JSON array literal, e.g.
+// `["a,b", "c"]`.  The string items are unquoted and the other ones, such
+// as numbers or nested objects, are kept as JSON to be parsed by the
+// element parser.
+func decodeJSONArray(v string) ([]string, bool) {
+	var raws []json.RawMessage
+
+	if !isJSONLiteral(v, '[', ']') || json.Unmarshal([]byte(v), &raws) != nil {
+		return nil, false
+	}
+
+	res := make([]string, len(raws))
+
+	for i, raw := range raws {
+		res[i] = jsonElement(raw)
+	}
+
+	return res, true
+}
+
+// decodeJSONObject decodes v when it is a JSON object literal, e.g.
+// `{"a": ["b", "c"]}`, the same way decodeJSONArray does for its values.
+func decodeJSONObject(v string) (map[string]string, bool) {
+	var raws map[string]json.RawMessage
+
+	if !isJSONLiteral(v, '{', '}') || json.Unmarshal([]byte(v), &raws) != nil {
+		return nil, false
+	}
+
+	res := make(map[string]string, len(raws))
+
+	for k, raw := range raws {
+		res[k] = jsonElement(raw)
+	}
+
+	return res, true
+}
+
+func isJSONLiteral(v string, start, end byte) bool {
+	v = strings.TrimSpace(v)
+
+	return len(v) >= 2 && v[0] == start && v[len(v)-1] == end
+}
+
+func jsonElement(raw json.RawMessage) string {
+	var s string
+
+	if json.Unmarshal(raw, &s) == nil {
+		return s
+	}
+
+	return string(raw)
+}
@@ -58,8 +58,17 @@ func IsUnmarshaler(t reflect.Type) bool {
 	return false
 }
 
+// IsSettable reports whether the values of type t are set as a whole by
+// the factory, their fields being left out of the configuration.
+func IsSettable(f Factory, t reflect.Type) bool {
+	return IsUnmarshaler(t) || f.Build(t) != nil
+}
+
 type factoryOptions struct {
 	tpo timeParserOption
+
+	typeParsers map[reflect.Type]customParserSpec
+	kindParsers []kindParser
 }
 
 func WithDateFormat(fmt string) FactoryOption {
@@ -106,10 +115,18 @@ func (df *defaultFactory) buildBasicParser(t reflect.Type) (parser, bool) {
 		pt = reflect.PtrTo(t)
 	}
 
+	if p := df.opts.customParser(t); p != nil {
+		return p, ptr
+	}
+
 	if p, ok := presetParsers[t]; ok {
 		return p(df.opts), ptr
 	}
 
+	if spec, ok := builtinParsers[t]; ok {
+		return &customParser{t: t, spec: spec}, ptr
+	}
+
 	for it, fn := range interfaceParsers {
 		if pt.Implements(it) {
 			return &interfaceParser{t: t, fn: fn}, ptr
@@ -291,6 +308,18 @@ func (mp *mapParser) String() string {
 }
 
 func (mp *mapParser) parse(v string, ptr bool) (interface{}, error) {
+	if kvs, ok := decodeJSONObject(v); ok {
+		res := reflect.MakeMapWithSize(mp.t, len(kvs))
+
+		for kv, vv := range kvs {
+			if err := mp.setIndex(res, kv, vv); err != nil {
+				return nil, err
+			}
+		}
+
+		return res.Interface(), nil
+	}
+
 	args, err := stringutil.Split(v, ',')
 
 	if err != nil {
@@ -310,22 +339,30 @@ func (mp *mapParser) parse(v string, ptr bool) (interface{}, error) {
 			continue
 		}
 
-		k, err := mp.kp.parse(vs[0], mp.kptr)
-
-		if err != nil {
+		if err := mp.setIndex(res, vs[0], strings.Join(vs[1:], "=")); err != nil {
 			return nil, err
 		}
+	}
 
-		v, err := mp.vp.parse(strings.Join(vs[1:], "="), mp.vptr)
+	return res.Interface(), nil
+}
 
-		if err != nil {
-			return nil, err
-		}
+func (mp *mapParser) setIndex(m reflect.Value, kv, vv string) error {
+	k, err := mp.kp.parse(kv, mp.kptr)
 
-		res.SetMapIndex(reflect.ValueOf(k), reflect.ValueOf(v))
+	if err != nil {
+		return err
 	}
 
-	return res.Interface(), nil
+	v, err := mp.vp.parse(vv, mp.vptr)
+
+	if err != nil {
+		return err
+	}
+
+	m.SetMapIndex(reflect.ValueOf(k), reflect.ValueOf(v))
+
+	return nil
 }
 
 type sliceParser struct {
@@ -340,10 +377,14 @@ func (sp *sliceParser) String() string {
 }
 
 func (sp *sliceParser) parse(v string, ptr bool) (interface{}, error) {
-	args, err := stringutil.Split(v, ',')
+	args, ok := decodeJSONArray(v)
 
-	if err != nil {
-		return nil, errors.Wrapf(err, "%q is not a correct slice value", v)
+	if !ok {
+		var err error
+
+		if args, err = stringutil.Split(v, ','); err != nil {
+			return nil, errors.Wrapf(err, "%q is not a correct slice value", v)
+		}
 	}
 
 	res := reflect.MakeSlice(sp.t, 0, len(args))
@@ -426,7 +467,20 @@ type timeParserOption struct {
 	dateFmt string
 }
 
-var defaultTimeParserOption = timeParserOption{dateFmt: "2006-01-02T15:04:05"}
+var (
+	defaultTimeParserOption = timeParserOption{dateFmt: "2006-01-02T15:04:05"}
+
+	// fallbackTimeLayouts are tried in order when a value does not match
+	// the configured date format, so the timestamps rendered by the file
+	// providers (offset date-times, local dates and local times) are
+	// accepted without losing precision.
+	fallbackTimeLayouts = []string{
+		time.RFC3339Nano,
+		defaultTimeParserOption.dateFmt,
+		time.DateOnly,
+		time.TimeOnly,
+	}
+)
 
 type timeParser struct {
 	opts timeParserOption
@@ -437,6 +491,16 @@ func (timeParser) String() string { return "time" }
 func (tp timeParser) parse(value string, ptr bool) (interface{}, error) {
 	t, err := time.Parse(tp.opts.dateFmt, value)
 
+	for _, layout := range fallbackTimeLayouts {
+		if err == nil {
+			break
+		}
+
+		if ft, ferr := time.Parse(layout, value); ferr == nil {
+			t, err = ft, nil
+		}
+	}
+
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,284 @@
+package setter
+
+import (
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/upfluence/errors"
+)
+
+// ByteSize is a number of bytes, parsed from a human size such as
+// "512MiB" or "1.5GB".  The decimal units (KB, MB, ...) are powers of 1000
+// and the binary ones (KiB, MiB, ...) powers of 1024.
+type ByteSize int64
+
+const (
+	B ByteSize = 1
+
+	KB ByteSize = 1000 * B
+	MB ByteSize = 1000 * KB
+	GB ByteSize = 1000 * MB
+	TB ByteSize = 1000 * GB
+	PB ByteSize = 1000 * TB
+
+	KiB ByteSize = 1024 * B
+	MiB ByteSize = 1024 * KiB
+	GiB ByteSize = 1024 * MiB
+	TiB ByteSize = 1024 * GiB
+	PiB ByteSize = 1024 * TiB
+)
+
+type unit[T any] struct {
+	name string
+	v    T
+}
+
+var (
+	byteSizeUnits = []unit[ByteSize]{
+		{"PiB", PiB}, {"TiB", TiB}, {"GiB", GiB}, {"MiB", MiB}, {"KiB", KiB},
+		{"PB", PB}, {"TB", TB}, {"GB", GB}, {"MB", MB}, {"KB", KB},
+		{"B", B},
+	}
+
+	quantityUnits = []unit[float64]{
+		{"P", 1e15}, {"T", 1e12}, {"G", 1e9}, {"M", 1e6}, {"k", 1e3},
+	}
+
+	rateUnits = []unit[time.Duration]{
+		{"s", time.Second}, {"m", time.Minute}, {"h", time.Hour},
+	}
+)
+
+// splitNumber splits v into its leading decimal number and the unit
+// following it.
+func splitNumber(v string) (float64, string, error) {
+	v = strings.TrimSpace(v)
+
+	i := strings.IndexFunc(
+		v,
+		func(r rune) bool { return (r < '0' || r > '9') && r != '.' },
+	)
+
+	if i < 0 {
+		i = len(v)
+	}
+
+	n, err := strconv.ParseFloat(v[:i], 64)
+
+	if err != nil {
+		return 0, "", errors.Newf("%q does not start with a number", v)
+	}
+
+	return n, strings.TrimSpace(v[i:]), nil
+}
+
+func toInt64(v string, n float64) (int64, error) {
+	// float64(math.MaxInt64) rounds up to 2^63, out of the int64 range.
+	if n != math.Trunc(n) || n >= math.MaxInt64 || n < math.MinInt64 {
+		return 0, errors.Newf("%q is not a whole number", v)
+	}
+
+	return int64(n), nil
+}
+
+// ParseByteSize parses a number of bytes, the unit being optional and
+// case insensitive.
+func ParseByteSize(v string) (ByteSize, error) {
+	n, u, err := splitNumber(v)
+
+	if err != nil {
+		return 0, err
+	}
+
+	mult := B
+
+	if u != "" {
+		mult = 0
+
+		for _, bu := range byteSizeUnits {
+			if strings.EqualFold(u, bu.name) {
+				mult = bu.v
+				break
+			}
+		}
+
+		if mult == 0 {
+			return 0, errors.Newf("unknown byte size unit %q", u)
+		}
+	}
+
+	bs, err := toInt64(v, n*float64(mult))
+
+	return ByteSize(bs), err
+}
+
+// String returns the size in the largest unit it is a whole number of.
+func (b ByteSize) String() string {
+	for _, bu := range byteSizeUnits {
+		if b != 0 && b%bu.v == 0 {
+			return strconv.FormatInt(int64(b/bu.v), 10) + bu.name
+		}
+	}
+
+	return "0B"
+}
+
+// Quantity is a count parsed with an optional SI suffix, e.g. "10k" or
+// "1.5M".
+type Quantity int64
+
+func parseQuantity(v string) (float64, error) {
+	n, u, err := splitNumber(v)
+
+	if err != nil || u == "" {
+		return n, err
+	}
+
+	for _, qu := range quantityUnits {
+		if u == qu.name || (qu.name == "k" && u == "K") {
+			return n * qu.v, nil
+		}
+	}
+
+	return 0, errors.Newf("unknown quantity unit %q", u)
+}
+
+func formatQuantity(n float64) string {
+	for _, qu := range quantityUnits {
+		if math.Abs(n) >= qu.v {
+			return strconv.FormatFloat(n/qu.v, 'f', -1, 64) + qu.name
+		}
+	}
+
+	return strconv.FormatFloat(n, 'f', -1, 64)
+}
+
+func ParseQuantity(v string) (Quantity, error) {
+	n, err := parseQuantity(v)
+
+	if err != nil {
+		return 0, err
+	}
+
+	q, err := toInt64(v, n)
+
+	return Quantity(q), err
+}
+
+func (q Quantity) String() string { return formatQuantity(float64(q)) }
+
+// Rate is a number of events per second, parsed from a quantity per
+// period, e.g. "10k/s", "100/m" or "5/100ms".
+type Rate float64
+
+func ParseRate(v string) (Rate, error) {
+	q, per, ok := strings.Cut(v, "/")
+
+	if !ok {
+		return 0, errors.Newf("%q is not a rate, e.g. 10/s", v)
+	}
+
+	n, err := parseQuantity(q)
+
+	if err != nil {
+		return 0, err
+	}
+
+	per = strings.TrimSpace(per)
+
+	if per == "min" {
+		per = "m"
+	}
+
+	if per != "" && (per[0] < '0' || per[0] > '9') {
+		per = "1" + per
+	}
+
+	d, err := time.ParseDuration(per)
+
+	if err != nil || d <= 0 {
+		return 0, errors.Newf("%q is not a valid rate period", per)
+	}
+
+	return Rate(n / d.Seconds()), nil
+}
+
+// String returns the rate per second, or per minute or hour for the rates
+// of less than one event per second.
+func (r Rate) String() string {
+	for _, ru := range rateUnits {
+		if n := float64(r) * ru.v.Seconds(); n >= 1 || ru.v == time.Hour {
+			return formatQuantity(n) + "/" + ru.name
+		}
+	}
+
+	return ""
+}
+
+// Percent is a ratio parsed from a percentage, e.g. "50%" is 0.5, or from
+// a plain ratio such as "0.5".
+type Percent float64
+
+func ParsePercent(v string) (Percent, error) {
+	v = strings.TrimSpace(v)
+
+	s, pct := strings.CutSuffix(v, "%")
+	s = strings.TrimSpace(s)
+
+	// The decimal point is moved by the exponent rather than by dividing
+	// by 100, so that "7%" is exactly 0.07.
+	if pct && !strings.ContainsAny(s, "eE") {
+		s += "e-2"
+		pct = false
+	}
+
+	n, err := strconv.ParseFloat(s, 64)
+
+	if err != nil {
+		return 0, errors.Newf("%q is not a percentage", v)
+	}
+
+	if pct {
+		n /= 100
+	}
+
+	return Percent(n), nil
+}
+
+// String returns the percentage, the decimal point of the shortest
+// representation of the ratio being moved so that it parses back to the
+// same value.
+func (p Percent) String() string {
+	return shiftDecimalPoint(float64(p), 2) + "%"
+}
+
+func shiftDecimalPoint(v float64, shift int) string {
+	if v == 0 || math.IsInf(v, 0) || math.IsNaN(v) {
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	}
+
+	var sign string
+
+	if v < 0 {
+		sign = "-"
+	}
+
+	mant, exp, _ := strings.Cut(strconv.FormatFloat(math.Abs(v), 'e', -1, 64), "e")
+	e, _ := strconv.Atoi(exp)
+
+	var (
+		digits = strings.Replace(mant, ".", "", 1)
+		point  = 1 + e + shift
+	)
+
+	switch {
+	case point <= 0:
+		return sign + "0." + strings.Repeat("0", -point) + digits
+	case point >= len(digits):
+		return sign + digits + strings.Repeat("0", point-len(digits))
+	}
+
+	return sign + digits[:point] + "." + digits[point:]
+}
@@ -0,0 +1,107 @@
+package stringutil
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// Stringify renders a value decoded from a structured document (JSON,
+// YAML, ...) in the format expected by the setters: sequences are
+// rendered as CSV records, maps as comma separated key=value pairs and
+// timestamps in the RFC3339 format with their full precision.  The
+// sequences and maps holding other sequences or maps, or map values the
+// comma syntax can not express, are rendered as JSON literals.
+func Stringify(v interface{}) string {
+	if t, ok := v.(time.Time); ok {
+		return t.Format(time.RFC3339Nano)
+	}
+
+	vv := reflect.ValueOf(v)
+
+	if needsJSON(vv) {
+		if buf, err := json.Marshal(v); err == nil {
+			return string(buf)
+		}
+	}
+
+	switch vv.Kind() {
+	case reflect.Slice:
+		var vs []string
+
+		for i := 0; i < vv.Len(); i++ {
+			vs = append(vs, Stringify(vv.Index(i).Interface()))
+		}
+
+		var b strings.Builder
+
+		w := csv.NewWriter(&b)
+
+		if err := w.Write(vs); err != nil {
+			return strings.Join(vs, ",")
+		}
+
+		w.Flush()
+
+		if res := b.String(); len(res) > 0 {
+			return res[:len(res)-1]
+		}
+
+		return strings.Join(vs, ",")
+	case reflect.Map:
+		var vs []string
+
+		for _, mkv := range vv.MapKeys() {
+			mvv := vv.MapIndex(mkv)
+
+			vs = append(
+				vs,
+				fmt.Sprintf(
+					"%s=%s",
+					Stringify(mkv.Interface()),
+					Stringify(mvv.Interface()),
+				),
+			)
+		}
+
+		return strings.Join(vs, ",")
+	}
+
+	return fmt.Sprintf("%v", v)
+}
+
+func isCollection(v reflect.Value) bool {
+	for v.Kind() == reflect.Interface || v.Kind() == reflect.Ptr {
+		v = v.Elem()
+	}
+
+	return v.Kind() == reflect.Slice || v.Kind() == reflect.Map
+}
+
+func needsJSON(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Slice:
+		for i := 0; i < v.Len(); i++ {
+			if isCollection(v.Index(i)) {
+				return true
+			}
+		}
+	case reflect.Map:
+		for _, k := range v.MapKeys() {
+			mv := v.MapIndex(k)
+
+			if isCollection(mv) {
+				return true
+			}
+
+			if s, ok := mv.Interface().(string); ok && strings.ContainsAny(s, ",=") {
+				return true
+			}
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,45 @@
+package stringutil
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStringify(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		in   interface{}
+		want string
+	}{
+		{name: "scalar", in: 42, want: "42"},
+		{
+			name: "time",
+			in:   time.Date(2024, 1, 2, 3, 4, 5, 6, time.UTC),
+			want: "2024-01-02T03:04:05.000000006Z",
+		},
+		{name: "slice", in: []interface{}{"a", "b"}, want: "a,b"},
+		{name: "slice with comma", in: []interface{}{"a,b", "c"}, want: "\"a,b\",c"},
+		{name: "map", in: map[string]interface{}{"a": "b"}, want: "a=b"},
+		{
+			name: "map with comma",
+			in:   map[string]interface{}{"a": "b,c"},
+			want: `{"a":"b,c"}`,
+		},
+		{
+			name: "nested map",
+			in:   map[string]interface{}{"a": []interface{}{"b", "c"}},
+			want: `{"a":["b","c"]}`,
+		},
+		{
+			name: "nested slice",
+			in:   []interface{}{map[string]interface{}{"a": 1}},
+			want: `[{"a":1}]`,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Stringify(tt.in))
+		})
+	}
+}
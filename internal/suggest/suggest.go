@@ -0,0 +1,80 @@
+package suggest
+
+import "sort"
+
+// Distance returns the Levenshtein distance between a and b.
+func Distance(a, b string) int {
+	var (
+		ra, rb = []rune(a), []rune(b)
+
+		prev = make([]int, len(rb)+1)
+		cur  = make([]int, len(rb)+1)
+	)
+
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		cur[0] = i
+
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+
+		prev, cur = cur, prev
+	}
+
+	return prev[len(rb)]
+}
+
+// Closest returns the candidates close enough to s to be a typo of it,
+// the closest first.  The maximum distance allowed is a third of the
+// length of s, and at least one.
+func Closest(s string, candidates []string) []string {
+	type match struct {
+		candidate string
+		distance  int
+	}
+
+	var (
+		ms  []match
+		max = len([]rune(s)) / 3
+	)
+
+	if max < 1 {
+		max = 1
+	}
+
+	for _, c := range candidates {
+		if c == s {
+			continue
+		}
+
+		if d := Distance(s, c); d <= max {
+			ms = append(ms, match{candidate: c, distance: d})
+		}
+	}
+
+	sort.Slice(ms, func(i, j int) bool {
+		if ms[i].distance != ms[j].distance {
+			return ms[i].distance < ms[j].distance
+		}
+
+		return ms[i].candidate < ms[j].candidate
+	})
+
+	res := make([]string, len(ms))
+
+	for i, m := range ms {
+		res[i] = m.candidate
+	}
+
+	return res
+}
@@ -0,0 +1,63 @@
+package suggest
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDistance(t *testing.T) {
+	for _, tt := range []struct {
+		a, b string
+		want int
+	}{
+		{want: 0},
+		{a: "foo", want: 3},
+		{b: "foo", want: 3},
+		{a: "server", b: "server", want: 0},
+		{a: "serer", b: "server", want: 1},
+		{a: "sevrer", b: "server", want: 2},
+		{a: "kitten", b: "sitting", want: 3},
+		{a: "héllo", b: "hello", want: 1},
+	} {
+		assert.Equal(t, tt.want, Distance(tt.a, tt.b), "%q %q", tt.a, tt.b)
+	}
+}
+
+func TestClosest(t *testing.T) {
+	for _, tt := range []struct {
+		name       string
+		s          string
+		candidates []string
+		want       []string
+	}{
+		{
+			name:       "no candidate",
+			s:          "foo",
+			candidates: []string{"server", "client"},
+			want:       []string{},
+		},
+		{
+			name:       "typo",
+			s:          "serer",
+			candidates: []string{"client", "server", "sever", "serve"},
+			want:       []string{"server", "sever"},
+		},
+		{
+			name:       "short word",
+			s:          "ls",
+			candidates: []string{"rm", "l", "lst"},
+			want:       []string{"l", "lst"},
+		},
+		{
+			name:       "ordered by distance",
+			s:          "--prot",
+			candidates: []string{"--port", "--pro", "--proto"},
+			want:       []string{"--pro", "--proto", "--port"},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Closest(tt.s, tt.candidates))
+		})
+	}
+}
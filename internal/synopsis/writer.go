@@ -49,17 +49,11 @@ func (w *Writer) buildWriteFn(b *bytes.Buffer) walker.WalkFunc {
 			w.IgnoreMissingTag,
 		)
 
-		if len(fks) == 0 {
-			return nil
+		if len(fks) > 0 {
+			w.writeKeys(b, fks)
 		}
 
-		if setter.IsUnmarshaler(f.Value.Type()) {
-			return walker.SkipStruct
-		}
-
-		w.writeKeys(b, fks)
-
-		return nil
+		return walker.SkipStruct
 	}
 }
 
@@ -0,0 +1,462 @@
+package template
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+
+	"github.com/upfluence/errors"
+	"gopkg.in/yaml.v3"
+
+	"github.com/upfluence/cfg/internal/help"
+	"github.com/upfluence/cfg/internal/redact"
+	"github.com/upfluence/cfg/internal/setter"
+	"github.com/upfluence/cfg/internal/validator"
+	"github.com/upfluence/cfg/internal/walker"
+	"github.com/upfluence/cfg/provider"
+	"github.com/upfluence/cfg/provider/env"
+	pjson "github.com/upfluence/cfg/provider/json"
+	pyaml "github.com/upfluence/cfg/provider/yaml"
+)
+
+type Format string
+
+const (
+	Env  Format = "env"
+	JSON Format = "json"
+	YAML Format = "yaml"
+)
+
+var (
+	Formats = []Format{Env, JSON, YAML}
+
+	ErrUnknownFormat   = errors.New("unknown template format")
+	ErrConflictingKeys = errors.New("a key is both a value and a parent of other keys")
+
+	DefaultWriter = &Writer{Factory: setter.DefaultFactory, Env: env.NewDefaultProvider()}
+)
+
+// Writer renders a commented configuration template listing the fields
+// of the given structs along with their help message, type, default value
+// and constraints.  JSON having no comments, the JSON template only holds
+// the keys and their default values so that it can be loaded as is.
+type Writer struct {
+	Factory          setter.Factory
+	Env              provider.Provider
+	IgnoreMissingTag bool
+}
+
+type field struct {
+	key string
+
+	typ      string
+	help     string
+	dflt     string
+	required bool
+	rules    []validator.Rule
+
+	// literal tells whether the default value is a number or a boolean.
+	literal bool
+	secret  bool
+}
+
+// value returns the value written in the template, secrets being left
+// for the user to fill.
+func (f *field) value() string {
+	if f.secret {
+		return ""
+	}
+
+	return f.dflt
+}
+
+func (f *field) comments() []string {
+	var (
+		res   []string
+		attrs = []string{f.typ}
+	)
+
+	if f.help != "" {
+		res = append(res, f.help)
+	}
+
+	if f.required {
+		attrs = append(attrs, "required")
+	}
+
+	for _, r := range f.rules {
+		attrs = append(attrs, r.String())
+	}
+
+	if f.dflt != "" {
+		attrs = append(attrs, "default: "+f.dflt)
+	}
+
+	return append(res, strings.Join(attrs, ", "))
+}
+
+type collector struct {
+	w         *Writer
+	fqp       provider.FullyQualifiedProvider
+	fields    []*field
+	sequences map[string]struct{}
+}
+
+func (c *collector) walkFunc(f *walker.Field) error {
+	s := c.w.Factory.Build(f.Field.Type)
+
+	if s == nil {
+		return c.walkSubKeyField(f)
+	}
+
+	ks := walker.BuildFieldKeys(c.fqp, f, c.w.IgnoreMissingTag)
+
+	if len(ks) == 0 {
+		return walker.SkipStruct
+	}
+
+	fd := field{
+		key:      ks[0],
+		typ:      s.String(),
+		help:     help.FieldHelp(f),
+		dflt:     help.FieldDefault(f),
+		required: validator.IsRequired(f.Field),
+		rules:    validator.Rules(f.Field),
+		literal:  isLiteral(f.Field.Type),
+	}
+
+	if redact.IsSecret(f) {
+		fd.dflt = redact.String(fd.dflt)
+		fd.secret = true
+	}
+
+	c.fields = append(c.fields, &fd)
+
+	return walker.SkipStruct
+}
+
+func (c *collector) walkSubKeyField(f *walker.Field) error {
+	prefixed := walker.BuildSubKeyField(f)
+
+	if prefixed == nil {
+		return nil
+	}
+
+	if prefixed.SubKey == "<N>" {
+		if ks := walker.BuildFieldKeys(c.fqp, f, c.w.IgnoreMissingTag); len(ks) > 0 {
+			c.sequences[ks[0]] = struct{}{}
+		}
+	}
+
+	return errors.Wrap(walker.Walk(prefixed, c.walkFunc), "walk")
+}
+
+func isLiteral(t reflect.Type) bool {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	if setter.IsUnmarshaler(t) {
+		return false
+	}
+
+	switch k := t.Kind(); {
+	case k == reflect.Bool:
+		return true
+	case k >= reflect.Int && k <= reflect.Uint64:
+		return t.PkgPath() == "" || t.Name() == ""
+	case k == reflect.Float32 || k == reflect.Float64:
+		return true
+	}
+
+	return false
+}
+
+func (w *Writer) provider(f Format) (provider.Provider, error) {
+	switch f {
+	case Env:
+		if w.Env == nil {
+			return env.NewDefaultProvider(), nil
+		}
+
+		return w.Env, nil
+	case JSON:
+		return &pjson.Provider{}, nil
+	case YAML:
+		return &pyaml.Provider{}, nil
+	}
+
+	return nil, errors.Wrapf(ErrUnknownFormat, "%q", f)
+}
+
+func (w *Writer) collect(p provider.Provider, ins []interface{}) (*collector, error) {
+	c := collector{
+		w:         w,
+		fqp:       provider.WrapFullyQualifiedProvider(p),
+		sequences: make(map[string]struct{}),
+	}
+
+	for _, in := range ins {
+		if err := walker.Walk(in, c.walkFunc); err != nil {
+			return nil, errors.Wrap(err, "walk")
+		}
+	}
+
+	return &c, nil
+}
+
+func (w *Writer) Write(out io.Writer, f Format, ins ...interface{}) (int, error) {
+	p, err := w.provider(f)
+
+	if err != nil {
+		return 0, err
+	}
+
+	c, err := w.collect(p, ins)
+
+	if err != nil {
+		return 0, err
+	}
+
+	var buf bytes.Buffer
+
+	switch f {
+	case Env:
+		writeEnv(&buf, p, c.fields)
+	case JSON:
+		root, err := buildTree(c)
+
+		if err != nil {
+			return 0, err
+		}
+
+		writeJSON(&buf, root, 0)
+		buf.WriteByte('\n')
+	case YAML:
+		root, err := buildTree(c)
+
+		if err != nil {
+			return 0, err
+		}
+
+		if len(root.children) > 0 {
+			enc := yaml.NewEncoder(&buf)
+			enc.SetIndent(2)
+
+			if err := enc.Encode(yamlNode(root)); err != nil {
+				return 0, errors.Wrap(err, "encode")
+			}
+
+			if err := enc.Close(); err != nil {
+				return 0, errors.Wrap(err, "encode")
+			}
+		}
+	}
+
+	n, err := out.Write(buf.Bytes())
+
+	return n, errors.Wrap(err, "write")
+}
+
+func writeEnv(b *bytes.Buffer, p provider.Provider, fs []*field) {
+	kf, hasFormatter := p.(provider.KeyFormatter)
+
+	for i, f := range fs {
+		if i > 0 {
+			b.WriteByte('\n')
+		}
+
+		for _, c := range f.comments() {
+			fmt.Fprintf(b, "# %s\n", c)
+		}
+
+		k := f.key
+
+		if hasFormatter {
+			k = kf.FormatKey(k)
+		}
+
+		v := f.value()
+
+		if v == "" {
+			fmt.Fprintf(b, "# %s=\n", k)
+			continue
+		}
+
+		fmt.Fprintf(b, "%s=%s\n", k, quoteEnv(v))
+	}
+}
+
+func quoteEnv(v string) string {
+	if !strings.ContainsAny(v, " \t\n#\"'\\$") {
+		return v
+	}
+
+	r := strings.NewReplacer(`\`, `\\`, `"`, `\"`, `$`, `\$`, "\n", `\n`)
+
+	return `"` + r.Replace(v) + `"`
+}
+
+type node struct {
+	key      string
+	field    *field
+	sequence bool
+
+	children []*node
+	index    map[string]*node
+}
+
+func (n *node) child(k string) *node {
+	if c, ok := n.index[k]; ok {
+		return c
+	}
+
+	c := &node{key: k, index: make(map[string]*node)}
+
+	n.children = append(n.children, c)
+	n.index[k] = c
+
+	return c
+}
+
+func buildTree(c *collector) (*node, error) {
+	root := &node{index: make(map[string]*node)}
+
+	for _, f := range c.fields {
+		var (
+			cur   = root
+			parts = strings.Split(f.key, ".")
+		)
+
+		for i, p := range parts {
+			if cur.field != nil {
+				return nil, errors.Wrapf(ErrConflictingKeys, "%q", f.key)
+			}
+
+			cur = cur.child(p)
+
+			if _, ok := c.sequences[strings.Join(parts[:i+1], ".")]; ok {
+				cur.sequence = true
+			}
+		}
+
+		if cur.field != nil || len(cur.children) > 0 {
+			return nil, errors.Wrapf(ErrConflictingKeys, "%q", f.key)
+		}
+
+		cur.field = f
+	}
+
+	return root, nil
+}
+
+func jsonValue(f *field) string {
+	v := f.value()
+
+	if v == "" {
+		return "null"
+	}
+
+	if f.literal && json.Valid([]byte(v)) {
+		return v
+	}
+
+	return jsonString(v)
+}
+
+func jsonString(s string) string {
+	var b bytes.Buffer
+
+	enc := json.NewEncoder(&b)
+	enc.SetEscapeHTML(false)
+	_ = enc.Encode(s)
+
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+func writeJSON(b *bytes.Buffer, n *node, depth int) {
+	if n.field != nil {
+		b.WriteString(jsonValue(n.field))
+		return
+	}
+
+	if n.sequence {
+		b.WriteString("[\n")
+		b.WriteString(strings.Repeat("  ", depth+1))
+
+		for _, c := range n.children {
+			writeJSON(b, c, depth+1)
+		}
+
+		b.WriteByte('\n')
+		b.WriteString(strings.Repeat("  ", depth))
+		b.WriteByte(']')
+
+		return
+	}
+
+	if len(n.children) == 0 {
+		b.WriteString("{}")
+		return
+	}
+
+	indent := strings.Repeat("  ", depth+1)
+
+	b.WriteString("{\n")
+
+	for i, c := range n.children {
+		fmt.Fprintf(b, "%s%s: ", indent, jsonString(c.key))
+		writeJSON(b, c, depth+1)
+
+		if i < len(n.children)-1 {
+			b.WriteByte(',')
+		}
+
+		b.WriteByte('\n')
+	}
+
+	b.WriteString(strings.Repeat("  ", depth))
+	b.WriteByte('}')
+}
+
+func yamlNode(n *node) *yaml.Node {
+	if f := n.field; f != nil {
+		v := f.value()
+
+		switch {
+		case v == "":
+			return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!null", Value: "null"}
+		case f.literal:
+			return &yaml.Node{Kind: yaml.ScalarNode, Value: v}
+		}
+
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: v}
+	}
+
+	if n.sequence {
+		seq := yaml.Node{Kind: yaml.SequenceNode}
+
+		for _, c := range n.children {
+			seq.Content = append(seq.Content, yamlNode(c))
+		}
+
+		return &seq
+	}
+
+	m := yaml.Node{Kind: yaml.MappingNode}
+
+	for _, c := range n.children {
+		k := yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: c.key}
+
+		if c.field != nil {
+			k.HeadComment = strings.Join(c.field.comments(), "\n")
+		}
+
+		m.Content = append(m.Content, &k, yamlNode(c))
+	}
+
+	return &m
+}
@@ -0,0 +1,200 @@
+package template
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	pjson "github.com/upfluence/cfg/provider/json"
+)
+
+type dbConfig struct {
+	Host string `env:"HOST" json:"host" yaml:"host" help:"Database host" default:"localhost"`
+	Port int    `env:"PORT" json:"port" yaml:"port" min:"1"`
+}
+
+type appConfig struct {
+	Name     string        `env:"NAME" json:"name" yaml:"name" required:"true"`
+	Timeout  time.Duration `env:"TIMEOUT" json:"timeout" yaml:"timeout" default:"1s"`
+	Debug    bool          `env:"DEBUG" json:"debug" yaml:"debug" default:"true"`
+	Password string        `env:"PASSWORD" json:"password" yaml:"password" default:"changeme" secret:"true"`
+	DB       dbConfig      `env:"DB" json:"db" yaml:"db"`
+}
+
+type sliceStructConfig struct {
+	Workers []dbConfig `env:"WORKERS" json:"workers" yaml:"workers"`
+}
+
+type conflictingConfig struct {
+	A string `json:"a"`
+	B string `json:"a.b"`
+}
+
+func TestWrite(t *testing.T) {
+	for _, tt := range []struct {
+		name   string
+		in     interface{}
+		format Format
+		out    string
+		errFn  assert.ErrorAssertionFunc
+	}{
+		{
+			name:   "env",
+			in:     &appConfig{},
+			format: Env,
+			out: "# string, required\n" +
+				"# NAME=\n\n" +
+				"# duration, default: 1s\n" +
+				"TIMEOUT=1s\n\n" +
+				"# bool, default: true\n" +
+				"DEBUG=true\n\n" +
+				"# string, default: <redacted>\n" +
+				"# PASSWORD=\n\n" +
+				"# Database host\n" +
+				"# string, default: localhost\n" +
+				"DB_HOST=localhost\n\n" +
+				"# integer, min: 1\n" +
+				"# DB_PORT=\n",
+			errFn: assert.NoError,
+		},
+		{
+			name:   "json",
+			in:     &appConfig{},
+			format: JSON,
+			out: "{\n" +
+				"  \"name\": null,\n" +
+				"  \"timeout\": \"1s\",\n" +
+				"  \"debug\": true,\n" +
+				"  \"password\": null,\n" +
+				"  \"db\": {\n" +
+				"    \"host\": \"localhost\",\n" +
+				"    \"port\": null\n" +
+				"  }\n" +
+				"}\n",
+			errFn: assert.NoError,
+		},
+		{
+			name:   "json sequence",
+			in:     &sliceStructConfig{},
+			format: JSON,
+			out: "{\n" +
+				"  \"workers\": [\n" +
+				"    {\n" +
+				"      \"host\": \"localhost\",\n" +
+				"      \"port\": null\n" +
+				"    }\n" +
+				"  ]\n" +
+				"}\n",
+			errFn: assert.NoError,
+		},
+		{
+			name:   "yaml",
+			in:     &appConfig{},
+			format: YAML,
+			out: "# string, required\n" +
+				"name: null\n" +
+				"# duration, default: 1s\n" +
+				"timeout: 1s\n" +
+				"# bool, default: true\n" +
+				"debug: true\n" +
+				"# string, default: <redacted>\n" +
+				"password: null\n" +
+				"db:\n" +
+				"  # Database host\n" +
+				"  # string, default: localhost\n" +
+				"  host: localhost\n" +
+				"  # integer, min: 1\n" +
+				"  port: null\n",
+			errFn: assert.NoError,
+		},
+		{
+			name:   "yaml sequence",
+			in:     &sliceStructConfig{},
+			format: YAML,
+			out: "workers:\n" +
+				"  - # Database host\n" +
+				"    # string, default: localhost\n" +
+				"    host: localhost\n" +
+				"    # integer, min: 1\n" +
+				"    port: null\n",
+			errFn: assert.NoError,
+		},
+		{
+			name:   "conflicting keys",
+			in:     &conflictingConfig{},
+			format: JSON,
+			errFn: func(t assert.TestingT, err error, _ ...interface{}) bool {
+				return assert.ErrorIs(t, err, ErrConflictingKeys)
+			},
+		},
+		{
+			name:   "unknown format",
+			in:     &appConfig{},
+			format: "toml",
+			errFn: func(t assert.TestingT, err error, _ ...interface{}) bool {
+				return assert.ErrorIs(t, err, ErrUnknownFormat)
+			},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			var b bytes.Buffer
+
+			_, err := DefaultWriter.Write(&b, tt.format, tt.in)
+
+			tt.errFn(t, err)
+			assert.Equal(t, tt.out, b.String())
+		})
+	}
+}
+
+func TestWriteJSONLoadable(t *testing.T) {
+	for _, tt := range []struct {
+		in   interface{}
+		want map[string]string
+	}{
+		{
+			in:   &appConfig{},
+			want: map[string]string{"timeout": "1s", "debug": "true", "db.host": "localhost"},
+		},
+		{
+			in:   &sliceStructConfig{},
+			want: map[string]string{"workers.0.host": "localhost"},
+		},
+	} {
+		var b bytes.Buffer
+
+		_, err := DefaultWriter.Write(&b, JSON, tt.in)
+		require.NoError(t, err)
+
+		p := pjson.NewProviderFromReader(&b)
+
+		for k, want := range tt.want {
+			v, ok, err := p.Provide(context.Background(), k)
+
+			require.NoError(t, err)
+			assert.True(t, ok, k)
+			assert.Equal(t, want, v)
+		}
+
+		_, ok, err := p.Provide(context.Background(), "name")
+
+		require.NoError(t, err)
+		assert.False(t, ok)
+	}
+}
+
+func TestQuoteEnv(t *testing.T) {
+	for in, out := range map[string]string{
+		"foo":      "foo",
+		"foo bar":  `"foo bar"`,
+		`a"$b\`:    `"a\"\$b\\"`,
+		"a\nb":     `"a\nb"`,
+		"#comment": `"#comment"`,
+	} {
+		assert.Equal(t, out, quoteEnv(in))
+	}
+}
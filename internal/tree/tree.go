@@ -0,0 +1,164 @@
+// Package tree navigates documents decoded from structured file formats
+// (YAML, TOML, ...) into nested maps and sequences.
+package tree
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/upfluence/errors"
+)
+
+var ErrMalformated = errors.New("payload not formatted correctly")
+
+// Normalize converts every map[interface{}]interface{} node of v into a
+// map[string]interface{} so the result can be navigated with Lookup and
+// SubKeys.
+func Normalize(v interface{}) interface{} {
+	switch vv := v.(type) {
+	case map[interface{}]interface{}:
+		res := make(map[string]interface{}, len(vv))
+
+		for k, v := range vv {
+			res[fmt.Sprintf("%v", k)] = Normalize(v)
+		}
+
+		return res
+	case map[string]interface{}:
+		for k, v := range vv {
+			vv[k] = Normalize(v)
+		}
+
+		return vv
+	case []interface{}:
+		for i, v := range vv {
+			vv[i] = Normalize(v)
+		}
+
+		return vv
+	}
+
+	return v
+}
+
+func child(node interface{}, k string) (interface{}, error) {
+	switch n := node.(type) {
+	case map[string]interface{}:
+		return n[k], nil
+	case []interface{}:
+		idx, err := strconv.Atoi(k)
+
+		if err != nil {
+			return nil, ErrMalformated
+		}
+
+		if idx < 0 || idx >= len(n) {
+			return nil, nil
+		}
+
+		return n[idx], nil
+	}
+
+	return nil, ErrMalformated
+}
+
+// Lookup returns the value stored under the dot separated key.  Map
+// entries are addressed by their key and sequence items by their index.
+// ErrMalformated is returned when the key traverses a scalar value.
+func Lookup(root interface{}, key string) (interface{}, bool, error) {
+	cur := root
+
+	for k := range strings.SplitSeq(key, ".") {
+		next, err := child(cur, k)
+
+		if err != nil {
+			return nil, false, err
+		}
+
+		if next == nil {
+			return nil, false, nil
+		}
+
+		cur = next
+	}
+
+	return cur, true, nil
+}
+
+// SubKeys returns the keys of the map, or the indices of the sequence,
+// stored under the dot separated prefix.  An empty prefix enumerates
+// the root node.  A nil slice is returned when the prefix does not
+// point to a map or a sequence.
+func SubKeys(root interface{}, prefix string) []string {
+	cur := root
+
+	if prefix != "" {
+		v, ok, err := Lookup(root, prefix)
+
+		if err != nil || !ok {
+			return nil
+		}
+
+		cur = v
+	}
+
+	switch n := cur.(type) {
+	case map[string]interface{}:
+		keys := make([]string, 0, len(n))
+
+		for k := range n {
+			keys = append(keys, k)
+		}
+
+		return keys
+	case []interface{}:
+		keys := make([]string, len(n))
+
+		for i := range n {
+			keys[i] = strconv.Itoa(i)
+		}
+
+		return keys
+	}
+
+	return nil
+}
+
+// Keys returns the sorted dot separated keys of the scalar values of the
+// tree, the items of the sequences included.  Empty maps and sequences
+// hold no key.
+func Keys(root interface{}) []string {
+	var keys []string
+
+	collectKeys(root, "", &keys)
+	sort.Strings(keys)
+
+	return keys
+}
+
+func collectKeys(node interface{}, prefix string, keys *[]string) {
+	join := func(k string) string {
+		if prefix == "" {
+			return k
+		}
+
+		return prefix + "." + k
+	}
+
+	switch n := node.(type) {
+	case map[string]interface{}:
+		for k, v := range n {
+			collectKeys(v, join(k), keys)
+		}
+	case []interface{}:
+		for i, v := range n {
+			collectKeys(v, join(strconv.Itoa(i)), keys)
+		}
+	default:
+		if prefix != "" {
+			*keys = append(*keys, prefix)
+		}
+	}
+}
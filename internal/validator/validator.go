@@ -0,0 +1,299 @@
+package validator
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/upfluence/errors"
+
+	"github.com/upfluence/cfg/internal/reflectutil"
+	"github.com/upfluence/cfg/internal/setter"
+	"github.com/upfluence/cfg/internal/stringutil"
+)
+
+var ErrInvalidRule = errors.New("invalid validation rule")
+
+type checkFunc func(setter.Factory, string, reflect.Value) error
+
+type ruleDefinition struct {
+	name  string
+	check checkFunc
+}
+
+var ruleDefinitions = []ruleDefinition{
+	{name: "nonempty", check: checkNonEmpty},
+	{name: "len", check: checkLen},
+	{name: "min", check: checkMin},
+	{name: "max", check: checkMax},
+	{name: "oneof", check: checkOneOf},
+	{name: "pattern", check: checkPattern},
+}
+
+// Rule is a constraint declared by a struct tag of a field.
+type Rule struct {
+	Name string
+	Arg  string
+
+	check checkFunc
+}
+
+func (r Rule) String() string {
+	return fmt.Sprintf("%s: %s", r.Name, r.Arg)
+}
+
+// IsRequired reports whether the field is flagged with a truthy `required`
+// tag, the value of such a field having to be set by a provider.
+func IsRequired(f reflect.StructField) bool {
+	v, ok := f.Tag.Lookup("required")
+
+	if !ok {
+		return false
+	}
+
+	b, err := setter.ParseBool(v)
+
+	return err == nil && b
+}
+
+// Rules returns the constraints declared by the tags of the field, in a
+// stable order.
+func Rules(f reflect.StructField) []Rule {
+	var rs []Rule
+
+	for _, rd := range ruleDefinitions {
+		if arg, ok := f.Tag.Lookup(rd.name); ok {
+			rs = append(rs, Rule{Name: rd.name, Arg: arg, check: rd.check})
+		}
+	}
+
+	return rs
+}
+
+// Validate checks the value of the field against the constraints declared
+// by its tags.  It is only called for the fields a provider set, a
+// missing value being the concern of the required tag.  Rule arguments are
+// parsed with the setter of the field type, so a duration field accepts
+// `min:"1s"`.
+func Validate(factory setter.Factory, f reflect.StructField, v reflect.Value) error {
+	for _, r := range Rules(f) {
+		if err := r.check(factory, r.Arg, v); err != nil {
+			return errors.Wrapf(err, "%s rule", r.Name)
+		}
+	}
+
+	return nil
+}
+
+func isSized(k reflect.Kind) bool {
+	switch k {
+	case reflect.String, reflect.Slice, reflect.Map, reflect.Array:
+		return true
+	}
+
+	return false
+}
+
+func indirect(v reflect.Value) reflect.Value {
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return reflect.Zero(v.Type().Elem())
+		}
+
+		return v.Elem()
+	}
+
+	return v
+}
+
+func parseArg(factory setter.Factory, arg string, t reflect.Type) (reflect.Value, error) {
+	s := factory.Build(t)
+
+	if s == nil {
+		return reflect.Value{}, errors.Wrapf(ErrInvalidRule, "type %v can not be parsed", t)
+	}
+
+	rv := reflect.New(t).Elem()
+
+	if err := s.Set(arg, rv); err != nil {
+		return reflect.Value{}, errors.Wrap(ErrInvalidRule, err.Error())
+	}
+
+	return rv, nil
+}
+
+func parseLen(arg string) (int, error) {
+	n, err := strconv.Atoi(arg)
+
+	if err != nil || n < 0 {
+		return 0, errors.Wrapf(ErrInvalidRule, "%q is not a valid length", arg)
+	}
+
+	return n, nil
+}
+
+// compare returns -1, 0 or 1 depending on whether the value is lower,
+// equal or greater than the argument parsed for the value type.
+func compare(factory setter.Factory, arg string, v reflect.Value) (int, error) {
+	if isSized(v.Kind()) {
+		n, err := parseLen(arg)
+
+		if err != nil {
+			return 0, err
+		}
+
+		return compareOrdered(v.Len(), n), nil
+	}
+
+	av, err := parseArg(factory, arg, v.Type())
+
+	if err != nil {
+		return 0, err
+	}
+
+	switch k := v.Kind(); {
+	case k >= reflect.Int && k <= reflect.Int64:
+		return compareOrdered(v.Int(), av.Int()), nil
+	case k >= reflect.Uint && k <= reflect.Uint64:
+		return compareOrdered(v.Uint(), av.Uint()), nil
+	case k == reflect.Float32 || k == reflect.Float64:
+		return compareOrdered(v.Float(), av.Float()), nil
+	}
+
+	return 0, errors.Wrapf(ErrInvalidRule, "type %v is not ordered", v.Type())
+}
+
+func compareOrdered[T int | int64 | uint64 | float64](x, y T) int {
+	switch {
+	case x < y:
+		return -1
+	case x > y:
+		return 1
+	}
+
+	return 0
+}
+
+func describe(v reflect.Value) string {
+	if isSized(v.Kind()) {
+		return fmt.Sprintf("length %d", v.Len())
+	}
+
+	return fmt.Sprintf("value %v", v.Interface())
+}
+
+func checkMin(factory setter.Factory, arg string, v reflect.Value) error {
+	v = indirect(v)
+
+	c, err := compare(factory, arg, v)
+
+	if err != nil {
+		return err
+	}
+
+	if c < 0 {
+		return errors.Newf("%s is lower than %s", describe(v), arg)
+	}
+
+	return nil
+}
+
+func checkMax(factory setter.Factory, arg string, v reflect.Value) error {
+	v = indirect(v)
+
+	c, err := compare(factory, arg, v)
+
+	if err != nil {
+		return err
+	}
+
+	if c > 0 {
+		return errors.Newf("%s is greater than %s", describe(v), arg)
+	}
+
+	return nil
+}
+
+func checkLen(_ setter.Factory, arg string, v reflect.Value) error {
+	v = indirect(v)
+
+	if !isSized(v.Kind()) {
+		return errors.Wrapf(ErrInvalidRule, "type %v has no length", v.Type())
+	}
+
+	n, err := parseLen(arg)
+
+	if err != nil {
+		return err
+	}
+
+	if v.Len() != n {
+		return errors.Newf("length %d is not %d", v.Len(), n)
+	}
+
+	return nil
+}
+
+func checkNonEmpty(_ setter.Factory, arg string, v reflect.Value) error {
+	b, err := setter.ParseBool(arg)
+
+	if err != nil {
+		return errors.Wrap(ErrInvalidRule, err.Error())
+	}
+
+	if b && reflectutil.IsZero(v) {
+		return errors.New("value is empty")
+	}
+
+	return nil
+}
+
+func checkOneOf(factory setter.Factory, arg string, v reflect.Value) error {
+	v = indirect(v)
+
+	choices, err := stringutil.Split(arg, ',')
+
+	if err != nil {
+		return errors.Wrap(ErrInvalidRule, err.Error())
+	}
+
+	for _, choice := range choices {
+		cv, err := parseArg(factory, choice, v.Type())
+
+		if err != nil {
+			return err
+		}
+
+		if reflect.DeepEqual(cv.Interface(), v.Interface()) {
+			return nil
+		}
+	}
+
+	return errors.Newf("value %v is not one of %s", v.Interface(), strings.Join(choices, ", "))
+}
+
+func checkPattern(_ setter.Factory, arg string, v reflect.Value) error {
+	v = indirect(v)
+
+	re, err := regexp.Compile(arg)
+
+	if err != nil {
+		return errors.Wrap(ErrInvalidRule, err.Error())
+	}
+
+	var s string
+
+	if v.Kind() == reflect.String {
+		s = v.String()
+	} else {
+		s = stringutil.Stringify(v.Interface())
+	}
+
+	if !re.MatchString(s) {
+		return errors.Newf("value %q does not match %s", s, arg)
+	}
+
+	return nil
+}
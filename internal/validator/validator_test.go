@@ -0,0 +1,96 @@
+package validator
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/upfluence/cfg/internal/setter"
+)
+
+func TestValidate(t *testing.T) {
+	var (
+		one  = 1
+		zero = 0
+	)
+
+	for _, tc := range []struct {
+		name    string
+		tag     reflect.StructTag
+		value   interface{}
+		wantErr bool
+		wantIs  error
+	}{
+		{name: "no rule", value: 42},
+		{name: "min ok", tag: `min:"1"`, value: 1},
+		{name: "min ko", tag: `min:"1"`, value: 0, wantErr: true},
+		{name: "max uint", tag: `max:"10"`, value: uint8(11), wantErr: true},
+		{name: "max float", tag: `max:"1.5"`, value: 1.25},
+		{name: "min duration", tag: `min:"1s"`, value: time.Millisecond, wantErr: true},
+		{name: "min string length", tag: `min:"3"`, value: "ab", wantErr: true},
+		{name: "max slice length", tag: `max:"2"`, value: []int{1, 2}},
+		{name: "pointer", tag: `min:"1"`, value: &one},
+		{name: "nil pointer", tag: `min:"1"`, value: (*int)(nil), wantErr: true},
+		{name: "len ok", tag: `len:"2"`, value: map[string]int{"a": 1, "b": 2}},
+		{name: "len ko", tag: `len:"2"`, value: "abc", wantErr: true},
+		{name: "len unsized", tag: `len:"2"`, value: 1, wantIs: ErrInvalidRule},
+		{name: "oneof ok", tag: `oneof:"1,2,3"`, value: 2},
+		{name: "oneof ko", tag: `oneof:"a,b"`, value: "c", wantErr: true},
+		{name: "oneof invalid choice", tag: `oneof:"a,b"`, value: 1, wantIs: ErrInvalidRule},
+		{name: "pattern ok", tag: `pattern:"^[a-z]+$"`, value: "abc"},
+		{name: "pattern int", tag: `pattern:"^4"`, value: 42},
+		{name: "pattern ko", tag: `pattern:"^[a-z]+$"`, value: "ab1", wantErr: true},
+		{name: "pattern invalid", tag: `pattern:"("`, value: "a", wantIs: ErrInvalidRule},
+		{name: "nonempty ok", tag: `nonempty:"true"`, value: "a"},
+		{name: "nonempty ko", tag: `nonempty:"true"`, value: &zero, wantErr: true},
+		{name: "nonempty disabled", tag: `nonempty:"false"`, value: ""},
+		{name: "min unordered", tag: `min:"1"`, value: true, wantIs: ErrInvalidRule},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := Validate(
+				setter.DefaultFactory,
+				reflect.StructField{Name: "Field", Tag: tc.tag},
+				reflect.ValueOf(tc.value),
+			)
+
+			switch {
+			case tc.wantIs != nil:
+				assert.ErrorIs(t, err, tc.wantIs)
+			case tc.wantErr:
+				assert.Error(t, err)
+				assert.NotErrorIs(t, err, ErrInvalidRule)
+			default:
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestRules(t *testing.T) {
+	rs := Rules(reflect.StructField{Tag: `pattern:"^a" max:"3" min:"1"`})
+
+	var got []string
+
+	for _, r := range rs {
+		got = append(got, r.String())
+	}
+
+	assert.Equal(t, []string{"min: 1", "max: 3", "pattern: ^a"}, got)
+}
+
+func TestIsRequired(t *testing.T) {
+	for _, tc := range []struct {
+		tag  reflect.StructTag
+		want bool
+	}{
+		{},
+		{tag: `required:"true"`, want: true},
+		{tag: `required:"yes"`, want: true},
+		{tag: `required:"false"`},
+		{tag: `required:"maybe"`},
+	} {
+		assert.Equal(t, tc.want, IsRequired(reflect.StructField{Tag: tc.tag}), tc.tag)
+	}
+}
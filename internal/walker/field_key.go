@@ -59,9 +59,63 @@ func buildStructFieldKey(p provider.FullyQualifiedProvider, sf reflect.StructFie
 }
 
 func BuildFieldKeys(p provider.FullyQualifiedProvider, f *Field, ignoreMissingTag bool) []string {
+	return buildFieldKeys(p, f, ignoreMissingTag, nil)
+}
+
+// DeprecatedTag lists the former names of a field, as "name" for every
+// provider or as "tag=name" for the provider of the given struct tag.
+const DeprecatedTag = "deprecated"
+
+func deprecatedNames(tag string, sf reflect.StructField) []string {
+	v, ok := sf.Tag.Lookup(DeprecatedTag)
+
+	if !ok || v == "" {
+		return nil
+	}
+
+	var names []string
+
+	for _, n := range strings.Split(v, ",") {
+		if t, name, ok := strings.Cut(n, "="); ok {
+			if t != tag {
+				continue
+			}
+
+			n = name
+		}
+
+		if n != "" {
+			names = append(names, n)
+		}
+	}
+
+	return names
+}
+
+// BuildDeprecatedFieldKeys returns the keys the field was formerly read
+// from by the provider, built from its deprecated tag in place of the
+// name of the field.  Nil is returned when the provider does not read
+// the field.
+func BuildDeprecatedFieldKeys(p provider.FullyQualifiedProvider, f *Field, ignoreMissingTag bool) []string {
+	names := deprecatedNames(p.StructTag(), f.Field)
+
+	if len(names) == 0 || len(BuildFieldKeys(p, f, ignoreMissingTag)) == 0 {
+		return nil
+	}
+
+	return buildFieldKeys(p, f, ignoreMissingTag, names)
+}
+
+// buildFieldKeys builds the keys of the field, the names of the field
+// itself being replaced by leaf when given.
+func buildFieldKeys(p provider.FullyQualifiedProvider, f *Field, ignoreMissingTag bool, leaf []string) []string {
+	if ss, ok := Sources(f); ok && !isSource(p, ss) {
+		return nil
+	}
+
 	var fss [][]string
 
-	if ok := walkFields(f, func(sf reflect.StructField) bool {
+	fn := func(sf reflect.StructField) bool {
 		fs, ok := buildStructFieldKey(p, sf, ignoreMissingTag)
 
 		if !ok {
@@ -73,8 +127,18 @@ func BuildFieldKeys(p provider.FullyQualifiedProvider, f *Field, ignoreMissingTa
 		}
 
 		return true
-	}); !ok {
-		return nil
+	}
+
+	if leaf == nil {
+		if !walkFields(f, fn) {
+			return nil
+		}
+	} else {
+		if f.Ancestor != nil && !walkFields(f.Ancestor, fn) {
+			return nil
+		}
+
+		fss = append(fss, leaf)
 	}
 
 	if len(fss) == 0 {
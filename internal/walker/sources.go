@@ -0,0 +1,83 @@
+package walker
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/upfluence/cfg/provider"
+	dflt "github.com/upfluence/cfg/provider/default"
+)
+
+// SourcesTag lists the struct tags of the providers a field, or the
+// fields of a struct, are read from, in their order of precedence, e.g.
+// `sources:"env,json"`.  The tag of the nearest field among the field and
+// its ancestors applies.  The default values are read whatever the tag.
+const SourcesTag = "sources"
+
+// NameProvider builds the keys naming the fields, e.g. "Database.URL",
+// whatever their sources tag.
+var NameProvider = provider.WrapFullyQualifiedProvider(
+	provider.NewStaticProvider("", nil, nil),
+)
+
+// Sources returns the struct tags listed by the sources tag applying to
+// the field, false being returned when the field is read from every
+// provider.
+func Sources(f *Field) ([]string, bool) {
+	for ; f != nil; f = f.Ancestor {
+		v, ok := f.Field.Tag.Lookup(SourcesTag)
+
+		if !ok {
+			continue
+		}
+
+		var ss []string
+
+		for _, s := range strings.Split(v, ",") {
+			if s = strings.TrimSpace(s); s != "" && !slices.Contains(ss, s) {
+				ss = append(ss, s)
+			}
+		}
+
+		return ss, true
+	}
+
+	return nil, false
+}
+
+func isSource(p provider.Provider, ss []string) bool {
+	if _, ok := p.(dflt.Provider); ok || p == NameProvider {
+		return true
+	}
+
+	return slices.Contains(ss, p.StructTag())
+}
+
+// SortProviders returns the providers the field is read from, ordered as
+// its sources tag lists them, the default provider first.  The providers
+// are returned as is when the field has no sources tag.
+func SortProviders(ps []provider.Provider, f *Field) []provider.Provider {
+	ss, ok := Sources(f)
+
+	if !ok {
+		return ps
+	}
+
+	var res []provider.Provider
+
+	for _, p := range ps {
+		if _, ok := p.(dflt.Provider); ok {
+			res = append(res, p)
+		}
+	}
+
+	for _, s := range ss {
+		for _, p := range ps {
+			if _, ok := p.(dflt.Provider); !ok && p.StructTag() == s {
+				res = append(res, p)
+			}
+		}
+	}
+
+	return res
+}
@@ -0,0 +1,73 @@
+package walker
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/upfluence/cfg/provider"
+	dflt "github.com/upfluence/cfg/provider/default"
+)
+
+func TestSortProviders(t *testing.T) {
+	var (
+		env   = provider.NewStaticProvider("env", nil, nil)
+		json  = provider.NewStaticProvider("json", nil, nil)
+		flag  = provider.NewStaticProvider("flag", nil, nil)
+		none  = provider.NewStaticProvider("", nil, nil)
+		ps    = []provider.Provider{dflt.Provider{}, json, env, flag, none}
+		field = func(tag reflect.StructTag, ancestor *Field) *Field {
+			return &Field{Field: reflect.StructField{Name: "F", Tag: tag}, Ancestor: ancestor}
+		}
+	)
+
+	for _, tt := range []struct {
+		name string
+		f    *Field
+		want []provider.Provider
+	}{
+		{name: "no tag", f: field("", nil), want: ps},
+		{
+			name: "restricted and ordered",
+			f:    field(`sources:"flag, env"`, nil),
+			want: []provider.Provider{dflt.Provider{}, flag, env},
+		},
+		{
+			name: "inherited",
+			f:    field("", field(`sources:"json"`, nil)),
+			want: []provider.Provider{dflt.Provider{}, json},
+		},
+		{
+			name: "nearest tag",
+			f:    field(`sources:"env"`, field(`sources:"json"`, nil)),
+			want: []provider.Provider{dflt.Provider{}, env},
+		},
+		{
+			name: "defaults only",
+			f:    field(`sources:""`, nil),
+			want: []provider.Provider{dflt.Provider{}},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, SortProviders(ps, tt.f))
+
+			for _, p := range ps[1:] {
+				ks := BuildFieldKeys(provider.WrapFullyQualifiedProvider(p), tt.f, false)
+				assert.Equal(t, containsProvider(tt.want, p), len(ks) > 0, p.StructTag())
+			}
+
+			assert.NotEmpty(t, BuildFieldKeys(NameProvider, tt.f, false))
+		})
+	}
+}
+
+func containsProvider(ps []provider.Provider, p provider.Provider) bool {
+	for _, pp := range ps {
+		if pp == p {
+			return true
+		}
+	}
+
+	return false
+}
@@ -2,6 +2,7 @@ package walker
 
 import (
 	"reflect"
+	"strings"
 	"unicode"
 
 	"github.com/upfluence/errors"
@@ -164,3 +165,19 @@ func walk(v reflect.Value, fn WalkFunc, a *Field) error {
 
 	return nil
 }
+
+// Path returns the dot separated names of the field and its ancestors,
+// embedded structs being omitted.
+func (f *Field) Path() string {
+	var names []string
+
+	walkFields(f, func(sf reflect.StructField) bool {
+		if !sf.Anonymous {
+			names = append(names, sf.Name)
+		}
+
+		return true
+	})
+
+	return strings.Join(names, ".")
+}
@@ -0,0 +1,66 @@
+package cfg
+
+import (
+	"context"
+	"os"
+	"reflect"
+	"slices"
+
+	"github.com/upfluence/cfg/internal/interpolate"
+	"github.com/upfluence/cfg/internal/setter"
+	"github.com/upfluence/cfg/internal/walker"
+	dflt "github.com/upfluence/cfg/provider/default"
+	"github.com/upfluence/cfg/provider/env"
+)
+
+var (
+	ErrMalformedReference = interpolate.ErrMalformed
+	ErrUndefinedReference = interpolate.ErrUndefined
+	ErrReferenceCycle     = interpolate.ErrCycle
+)
+
+func (c *configurator) set(ctx context.Context, f *walker.Field, s setter.Setter, v string, fv reflect.Value) error {
+	if c.interpolate {
+		var err error
+
+		if v, err = interpolate.Expand(v, c.lookupReference(ctx, f)); err != nil {
+			return err
+		}
+	}
+
+	return s.Set(v, fv)
+}
+
+// lookupReference resolves a variable referenced by a value of the field
+// through the providers it is read from, the ones of highest precedence
+// first, and then through the process environment when the field is read
+// from the env providers.  The keys of the default provider being the
+// values themselves, it is skipped.
+func (c *configurator) lookupReference(ctx context.Context, f *walker.Field) interpolate.LookupFunc {
+	var (
+		ps          = walker.SortProviders(c.providers, f)
+		ss, limited = walker.Sources(f)
+	)
+
+	return func(k string) (string, bool, error) {
+		for i := len(ps) - 1; i >= 0; i-- {
+			p := ps[i]
+
+			if _, ok := p.(dflt.Provider); ok {
+				continue
+			}
+
+			if v, ok, err := p.Provide(ctx, k); err != nil || ok {
+				return v, ok, err
+			}
+		}
+
+		if limited && !slices.Contains(ss, env.StructTag) {
+			return "", false, nil
+		}
+
+		v, ok := os.LookupEnv(k)
+
+		return v, ok, nil
+	}
+}
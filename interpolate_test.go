@@ -0,0 +1,79 @@
+package cfg
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	dflt "github.com/upfluence/cfg/provider/default"
+)
+
+type interpolateConfig struct {
+	URL  string `mock:"url" default:"postgres://${db_user}@${db_host:-localhost}/app"`
+	Cost string `mock:"cost"`
+}
+
+func TestInterpolate(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		have    map[string]string
+		opts    []Option
+		want    interpolateConfig
+		wantErr error
+	}{
+		{
+			name: "disabled",
+			have: map[string]string{"db_user": "admin", "url": "${db_user}"},
+			want: interpolateConfig{URL: "${db_user}"},
+		},
+		{
+			name: "default tag expanded",
+			have: map[string]string{"db_user": "admin"},
+			opts: []Option{Interpolate},
+			want: interpolateConfig{URL: "postgres://admin@localhost/app"},
+		},
+		{
+			name: "escape",
+			have: map[string]string{"db_user": "admin", "cost": "$$5"},
+			opts: []Option{Interpolate},
+			want: interpolateConfig{URL: "postgres://admin@localhost/app", Cost: "$5"},
+		},
+		{
+			name:    "undefined",
+			have:    map[string]string{},
+			opts:    []Option{Interpolate},
+			wantErr: ErrUndefinedReference,
+		},
+		{
+			name:    "cycle",
+			have:    map[string]string{"db_user": "${url}", "url": "${db_user}"},
+			opts:    []Option{Interpolate},
+			wantErr: ErrReferenceCycle,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var c interpolateConfig
+
+			err := NewConfiguratorWithOptions(
+				append(
+					tc.opts,
+					WithProviders(dflt.Provider{}, &mockProvider{st: tc.have}),
+				)...,
+			).Populate(context.Background(), &c)
+
+			if tc.wantErr != nil {
+				var se *SettingError
+
+				require.ErrorAs(t, err, &se)
+				assert.ErrorIs(t, err, tc.wantErr)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, c)
+		})
+	}
+}
@@ -0,0 +1,92 @@
+package cfg
+
+import (
+	"sort"
+	"sync"
+)
+
+// KeyTracker records, for each struct tag, the keys the configurators
+// looked up while populating structs.  A tracker can be shared by several
+// Populate calls and configurators.
+type KeyTracker struct {
+	mu   sync.Mutex
+	keys map[string]map[string]struct{}
+}
+
+func NewKeyTracker() *KeyTracker {
+	return &KeyTracker{keys: make(map[string]map[string]struct{})}
+}
+
+// TrackKeys records in t every key Populate looks up, the alternative
+// keys of a field included.
+func TrackKeys(t *KeyTracker) Option {
+	return func(c *configurator) { c.tracker = t }
+}
+
+func (t *KeyTracker) record(tag string, ks []string) {
+	if t == nil || len(ks) == 0 {
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	tks, ok := t.keys[tag]
+
+	if !ok {
+		tks = make(map[string]struct{}, len(ks))
+		t.keys[tag] = tks
+	}
+
+	for _, k := range ks {
+		tks[k] = struct{}{}
+	}
+}
+
+// merge records in t the keys recorded in o.
+func (t *KeyTracker) merge(o *KeyTracker) {
+	if t == nil || o == nil {
+		return
+	}
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	for tag, ks := range o.keys {
+		tks := make([]string, 0, len(ks))
+
+		for k := range ks {
+			tks = append(tks, k)
+		}
+
+		t.record(tag, tks)
+	}
+}
+
+// Keys returns the sorted keys looked up for the providers of the given
+// struct tag.
+func (t *KeyTracker) Keys(tag string) []string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	ks := make([]string, 0, len(t.keys[tag]))
+
+	for k := range t.keys[tag] {
+		ks = append(ks, k)
+	}
+
+	sort.Strings(ks)
+
+	return ks
+}
+
+// Contains reports whether the key was looked up for the providers of
+// the given struct tag.
+func (t *KeyTracker) Contains(tag, k string) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	_, ok := t.keys[tag][k]
+
+	return ok
+}
@@ -0,0 +1,65 @@
+package cfg
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/upfluence/cfg/provider/flags"
+)
+
+type trackedDBConfig struct {
+	Host string `flag:"host"`
+}
+
+type trackedConfig struct {
+	Port      int                        `flag:"p,port"`
+	Name      string                     `flag:"-"`
+	Databases map[string]trackedDBConfig `flag:"databases"`
+}
+
+func TestTrackKeys(t *testing.T) {
+	var (
+		kt = NewKeyTracker()
+		c  = NewConfigurator(
+			flags.NewProvider([]string{"-p", "80", "--databases.main.host=h"}),
+		).WithOptions(TrackKeys(kt))
+	)
+
+	require.NoError(t, c.Populate(context.Background(), &trackedConfig{}))
+
+	assert.Equal(t, []string{"databases.main.host", "p", "port"}, kt.Keys("flag"))
+	assert.True(t, kt.Contains("flag", "port"))
+	assert.False(t, kt.Contains("flag", "name"))
+	assert.Empty(t, kt.Keys("env"))
+}
+
+func TestTrackKeysWithUnusedKeys(t *testing.T) {
+	var (
+		kt  = NewKeyTracker()
+		uks []UnusedKey
+
+		c = NewConfigurator(
+			jsonProvider(`{"timeout":"1s","databases":{"main":{"host":"h","prot":1}}}`),
+		).WithOptions(
+			TrackKeys(kt),
+			ReportUnusedKeys(func(_ context.Context, ks []UnusedKey) error {
+				uks = ks
+				return nil
+			}),
+		)
+	)
+
+	require.NoError(t, c.Populate(context.Background(), &unusedConfig{}))
+
+	require.Len(t, uks, 1)
+	assert.Equal(t, "databases.main.prot", uks[0].Key)
+
+	ks, err := UnusedKeys(context.Background(), kt, uks[0].Provider)
+
+	require.NoError(t, err)
+	assert.Equal(t, uks, ks)
+	assert.True(t, kt.Contains("json", "databases.main.host"))
+}
@@ -0,0 +1,134 @@
+package cfg
+
+import (
+	"context"
+	"reflect"
+
+	"github.com/upfluence/errors"
+
+	"github.com/upfluence/cfg/internal/setter"
+	"github.com/upfluence/cfg/internal/walker"
+)
+
+// MergeTag is the struct tag choosing how the values of a slice or map
+// field given by successive providers are combined:
+//
+//   - replace, the default, keeps the value of the last provider
+//   - append appends the items of the slices and adds the entries of the
+//     maps, the last provider overriding the keys already set
+//   - merge does the same, except that the slice items already present
+//     are not appended again
+const MergeTag = "merge"
+
+type mergeStrategy int
+
+const (
+	mergeReplace mergeStrategy = iota
+	mergeAppend
+	mergeMerge
+)
+
+var mergeStrategies = map[string]mergeStrategy{
+	"replace": mergeReplace,
+	"append":  mergeAppend,
+	"merge":   mergeMerge,
+}
+
+func (ms mergeStrategy) String() string {
+	for n, s := range mergeStrategies {
+		if s == ms {
+			return n
+		}
+	}
+
+	return "unknown"
+}
+
+func fieldMergeStrategy(f reflect.StructField) (mergeStrategy, error) {
+	v, ok := f.Tag.Lookup(MergeTag)
+
+	if !ok || v == "" {
+		return mergeReplace, nil
+	}
+
+	ms, ok := mergeStrategies[v]
+
+	if !ok {
+		return mergeReplace, errors.Newf(
+			"cfg: %s.%s has an unknown merge strategy %q, expected one of append, merge or replace",
+			f.Type.Name(),
+			f.Name,
+			v,
+		)
+	}
+
+	if !isMultiValued(f.Type) && ms != mergeReplace {
+		return mergeReplace, errors.Newf(
+			"cfg: %s.%s can not be merged, only the slices and maps can",
+			f.Type.Name(),
+			f.Name,
+		)
+	}
+
+	return ms, nil
+}
+
+// mergeValues sets the values to the field and combines the result with
+// the value already set by a previous provider.
+func (c *configurator) mergeValues(ctx context.Context, f *walker.Field, s setter.Setter, vs []string, fv reflect.Value, ms mergeStrategy) error {
+	if ms == mergeReplace {
+		return c.setValues(ctx, f, s, vs, fv)
+	}
+
+	v := reflect.New(fv.Type()).Elem()
+
+	if err := c.setValues(ctx, f, s, vs, v); err != nil {
+		return err
+	}
+
+	fv.Set(combineValues(fv, v, ms == mergeMerge))
+
+	return nil
+}
+
+// combineValues returns a new slice holding the items of dst followed by
+// the ones of src, or a new map holding the entries of both, the ones of
+// src taking precedence.  When dedupe is set, the items of src already
+// present are skipped.
+func combineValues(dst, src reflect.Value, dedupe bool) reflect.Value {
+	switch dst.Kind() {
+	case reflect.Slice:
+		res := reflect.MakeSlice(dst.Type(), 0, dst.Len()+src.Len())
+		res = reflect.AppendSlice(res, dst)
+
+		for i := 0; i < src.Len(); i++ {
+			if item := src.Index(i); !dedupe || !containsValue(res, item) {
+				res = reflect.Append(res, item)
+			}
+		}
+
+		return res
+	case reflect.Map:
+		res := reflect.MakeMapWithSize(dst.Type(), dst.Len()+src.Len())
+
+		for _, m := range []reflect.Value{dst, src} {
+			for it := m.MapRange(); it.Next(); {
+				res.SetMapIndex(it.Key(), it.Value())
+			}
+		}
+
+		return res
+	}
+
+	return src
+}
+
+func containsValue(s, v reflect.Value) bool {
+	for i := 0; i < s.Len(); i++ {
+		if reflect.DeepEqual(s.Index(i).Interface(), v.Interface()) {
+			return true
+		}
+	}
+
+	return false
+}
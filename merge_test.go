@@ -0,0 +1,129 @@
+package cfg
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/upfluence/cfg/provider"
+	"github.com/upfluence/cfg/provider/flags"
+	"github.com/upfluence/cfg/provider/json"
+)
+
+type mergeConfig struct {
+	Replaced []string          `json:"replaced" env:"REPLACED"`
+	Appended []string          `json:"appended" env:"APPENDED" merge:"append"`
+	Merged   []string          `json:"merged" env:"MERGED" merge:"merge"`
+	Labels   map[string]string `json:"labels" flag:"label" merge:"merge"`
+}
+
+func TestMerge(t *testing.T) {
+	var c mergeConfig
+
+	err := NewConfigurator(
+		json.NewProviderFromReader(
+			strings.NewReader(
+				`{
+					"replaced": ["a", "b"],
+					"appended": ["a", "b"],
+					"merged": ["a", "b"],
+					"labels": {"a": "b", "c": "d"}
+				}`,
+			),
+		),
+		provider.NewStaticProvider(
+			"env",
+			map[string]string{"REPLACED": "c", "APPENDED": "b,c", "MERGED": "b,c"},
+			nil,
+		),
+		flags.NewProvider([]string{"--label", "c=e", "--label", "f=g"}),
+	).Populate(context.Background(), &c)
+
+	require.NoError(t, err)
+	assert.Equal(
+		t,
+		mergeConfig{
+			Replaced: []string{"c"},
+			Appended: []string{"a", "b", "b", "c"},
+			Merged:   []string{"a", "b", "c"},
+			Labels:   map[string]string{"a": "b", "c": "e", "f": "g"},
+		},
+		c,
+	)
+}
+
+func TestMergeInvalidTag(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		in   interface{}
+		want string
+	}{
+		{
+			name: "unknown strategy",
+			in: &struct {
+				Tags []string `merge:"concat"`
+			}{},
+			want: `unknown merge strategy "concat"`,
+		},
+		{
+			name: "scalar field",
+			in: &struct {
+				Name string `merge:"append"`
+			}{},
+			want: "can not be merged",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			err := NewConfigurator().Populate(context.Background(), tt.in)
+
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.want)
+		})
+	}
+}
+
+func TestMergeReport(t *testing.T) {
+	var c mergeConfig
+
+	r, err := PopulateWithReport(
+		context.Background(),
+		NewConfigurator(
+			json.NewProviderFromReader(
+				strings.NewReader(`{"replaced": ["a"], "appended": ["a"], "labels": {"a": "b"}}`),
+			),
+			provider.NewStaticProvider(
+				"env",
+				map[string]string{"REPLACED": "b", "APPENDED": "b"},
+				nil,
+			),
+		),
+		&c,
+	)
+
+	require.NoError(t, err)
+
+	fr, ok := r.Lookup("Appended")
+
+	require.True(t, ok)
+	assert.Equal(
+		t,
+		FieldReport{
+			Path:   "Appended",
+			Source: Source{Provider: "env", Key: "APPENDED", Value: "b"},
+			Merge:  "append",
+			Merged: []Source{{Provider: "json", Key: "appended", Value: "a"}},
+		},
+		fr,
+	)
+	assert.Equal(
+		t,
+		"Replaced = env: REPLACED=\"b\" (overrides json: replaced=\"a\")\n"+
+			"Appended = env: APPENDED=\"b\" (merged from json: appended=\"a\")\n"+
+			"Merged: <unset>\n"+
+			"Labels = json: labels=\"a=b\"\n",
+		r.String(),
+	)
+}
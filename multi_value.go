@@ -0,0 +1,59 @@
+package cfg
+
+import (
+	"context"
+	"reflect"
+
+	"github.com/upfluence/cfg/internal/reflectutil"
+	"github.com/upfluence/cfg/internal/setter"
+	"github.com/upfluence/cfg/internal/walker"
+	"github.com/upfluence/cfg/provider"
+)
+
+// isMultiValued reports whether the fields of type t accumulate the
+// values of a key given several times, i.e. whether t is a slice, other
+// than []byte, or a map.
+func isMultiValued(t reflect.Type) bool {
+	switch t = reflectutil.IndirectedType(t); t.Kind() {
+	case reflect.Slice:
+		return t.Elem().Kind() != reflect.Uint8
+	case reflect.Map:
+		return true
+	}
+
+	return false
+}
+
+func provideValues(ctx context.Context, p provider.Provider, k string, multi bool) ([]string, bool, error) {
+	if mvp, ok := p.(provider.MultiValueProvider); ok && multi {
+		return mvp.ProvideValues(ctx, k)
+	}
+
+	v, ok, err := p.Provide(ctx, k)
+
+	return []string{v}, ok, err
+}
+
+// setValues sets each of the values to the field, appending the items of
+// the slices and merging the entries of the maps they hold.
+func (c *configurator) setValues(ctx context.Context, f *walker.Field, s setter.Setter, vs []string, fv reflect.Value) error {
+	if len(vs) == 1 {
+		return c.set(ctx, f, s, vs[0], fv)
+	}
+
+	acc := reflect.Zero(fv.Type())
+
+	for _, v := range vs {
+		tmp := reflect.New(fv.Type()).Elem()
+
+		if err := c.set(ctx, f, s, v, tmp); err != nil {
+			return err
+		}
+
+		acc = combineValues(acc, tmp, false)
+	}
+
+	fv.Set(acc)
+
+	return nil
+}
@@ -0,0 +1,95 @@
+package cfg
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/upfluence/cfg/provider"
+	"github.com/upfluence/cfg/provider/flags"
+	"github.com/upfluence/cfg/provider/json"
+)
+
+type multiValueConfig struct {
+	Tags   []string          `flag:"tag" env:"TAGS"`
+	Ports  []int             `flag:"port"`
+	Labels map[string]string `flag:"label"`
+	Name   string            `flag:"name"`
+	Groups map[string][]string
+}
+
+func TestMultiValues(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		ps   []provider.Provider
+		want multiValueConfig
+	}{
+		{
+			name: "repeated flags",
+			ps: []provider.Provider{
+				flags.NewProvider(
+					[]string{
+						"--tag", "a", "--tag", "b,c",
+						"--port", "80", "--port", "[443, 8080]",
+						"--label", "a=b", "--label", "c=d,e=f",
+						"--name", "foo", "--name", "bar",
+					},
+				),
+			},
+			want: multiValueConfig{
+				Tags:   []string{"a", "b", "c"},
+				Ports:  []int{80, 443, 8080},
+				Labels: map[string]string{"a": "b", "c": "d", "e": "f"},
+				Name:   "bar",
+			},
+		},
+		{
+			name: "json literals",
+			ps: []provider.Provider{
+				provider.NewStaticProvider(
+					"env",
+					map[string]string{"TAGS": `["a,b", "c"]`},
+					nil,
+				),
+			},
+			want: multiValueConfig{Tags: []string{"a,b", "c"}},
+		},
+		{
+			name: "nested json document",
+			ps: []provider.Provider{
+				json.NewProviderFromReader(
+					strings.NewReader(`{"Groups": {"admin": ["a", "b,c"]}}`),
+				),
+			},
+			want: multiValueConfig{
+				Groups: map[string][]string{"admin": {"a", "b,c"}},
+			},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			var c multiValueConfig
+
+			require.NoError(
+				t,
+				NewConfigurator(tt.ps...).Populate(context.Background(), &c),
+			)
+			assert.Equal(t, tt.want, c)
+		})
+	}
+}
+
+func TestMultiValuesError(t *testing.T) {
+	var c multiValueConfig
+
+	err := NewConfigurator(
+		flags.NewProvider([]string{"--port", "80", "--port", "foo"}),
+	).Populate(context.Background(), &c)
+
+	var se *SettingError
+
+	require.ErrorAs(t, err, &se)
+	assert.Equal(t, "80,foo", se.Value)
+}
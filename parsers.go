@@ -0,0 +1,41 @@
+package cfg
+
+import "github.com/upfluence/cfg/internal/setter"
+
+// ParserOption configures how the configurator parses the provided
+// values, see WithParsers.
+type ParserOption = setter.FactoryOption
+
+// ParseFunc parses the value of a type registered with WithTypeParser or
+// WithKindParser, t being the type of the field set.
+type ParseFunc = setter.ParseFunc
+
+var (
+	WithDateFormat = setter.WithDateFormat
+	WithTypeParser = setter.WithTypeParser
+	WithKindParser = setter.WithKindParser
+)
+
+// WithParser registers fn as the parser of the values of type T, name
+// being the type displayed in the help messages, e.g.
+// WithParser("url", url.Parse) sets the *url.URL and url.URL fields.
+func WithParser[T any](name string, fn func(string) (T, error)) ParserOption {
+	return setter.WithParser(name, fn)
+}
+
+// WithParsers sets the parsers of the types the configurator does not
+// support out of the box, or overrides the built-in ones.  The options
+// add up with the ones of the previous WithParsers calls.
+func WithParsers(opts ...ParserOption) Option {
+	return func(c *configurator) {
+		c.parserOpts = append(c.parserOpts[:len(c.parserOpts):len(c.parserOpts)], opts...)
+		c.factory = setter.NewDefaultFactory(c.parserOpts...)
+	}
+}
+
+// ParserFactory returns the factory the configurators built with opts
+// parse the values with, for the help messages and the other descriptions
+// of a struct to agree with them on the types of its fields.
+func ParserFactory(opts ...Option) setter.Factory {
+	return newConfigurator(opts).factory
+}
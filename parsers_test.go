@@ -0,0 +1,78 @@
+package cfg
+
+import (
+	"bytes"
+	"context"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/upfluence/cfg/provider"
+)
+
+type userID string
+
+type parsersConfig struct {
+	Endpoint *url.URL `env:"ENDPOINT"`
+	Mirror   url.URL  `env:"MIRROR"`
+	Owner    userID   `env:"OWNER"`
+	Name     string   `env:"NAME"`
+}
+
+var parserOpts = []ParserOption{
+	WithParser("url", url.Parse),
+	WithKindParser(
+		reflect.String,
+		func(t reflect.Type) bool { return t.PkgPath() != "" },
+		"id",
+		func(v string, _ reflect.Type) (interface{}, error) {
+			return strings.TrimPrefix(v, "user:"), nil
+		},
+	),
+}
+
+func TestWithParsers(t *testing.T) {
+	var c parsersConfig
+
+	err := NewConfigurator(
+		provider.NewStaticProvider(
+			"env",
+			map[string]string{
+				"ENDPOINT":      "https://example.com/api",
+				"MIRROR":        "https://mirror.example.com",
+				"MIRROR.SCHEME": "ftp",
+				"OWNER":         "user:42",
+				"NAME":          "user:foo",
+			},
+			nil,
+		),
+	).WithOptions(WithParsers(parserOpts...)).Populate(context.Background(), &c)
+
+	require.NoError(t, err)
+	assert.Equal(t, "https://example.com/api", c.Endpoint.String())
+	assert.Equal(t, "https://mirror.example.com", c.Mirror.String())
+	assert.Equal(t, userID("42"), c.Owner)
+	assert.Equal(t, "user:foo", c.Name)
+}
+
+func TestWithParsersHelp(t *testing.T) {
+	var b bytes.Buffer
+
+	cfg := NewDefaultConfigurator().WithOptions(WithParsers(parserOpts...)).(*helpConfigurator)
+	cfg.stderr = &b
+
+	require.NoError(t, cfg.PrintDefaults(&parsersConfig{}))
+	assert.Equal(
+		t,
+		"Arguments:\n"+
+			"\t- Endpoint: url (env: ENDPOINT, flag: --endpoint)\n"+
+			"\t- Mirror: url (env: MIRROR, flag: --mirror)\n"+
+			"\t- Owner: id (env: OWNER, flag: --owner)\n"+
+			"\t- Name: string (env: NAME, flag: --name)\n",
+		b.String(),
+	)
+}
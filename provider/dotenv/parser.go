@@ -0,0 +1,314 @@
+package dotenv
+
+import (
+	"io"
+	"os"
+	"strings"
+
+	"github.com/upfluence/errors"
+)
+
+// Environment holds the variables parsed from a dotenv file.  It
+// implements env.Environment.
+type Environment map[string]string
+
+func (e Environment) LookupEnv(k string) (string, bool) {
+	v, ok := e[k]
+
+	return v, ok
+}
+
+func (e Environment) Environ() []string {
+	res := make([]string, 0, len(e))
+
+	for k, v := range e {
+		res = append(res, k+"="+v)
+	}
+
+	return res
+}
+
+// Parse reads a dotenv file.  Each line holds a KEY=value assignment,
+// optionally prefixed by "export".  Values can be unquoted (a " #"
+// sequence starts a comment), single quoted (taken literally) or double
+// quoted (escape sequences are honored and the value can span several
+// lines).  References of the form ${VAR} in unquoted and double quoted
+// values are replaced by the value of VAR as defined by a previous line
+// or, failing that, by the process environment.
+func Parse(r io.Reader) (Environment, error) {
+	buf, err := io.ReadAll(r)
+
+	if err != nil {
+		return nil, errors.Wrap(err, "read")
+	}
+
+	p := parser{src: string(buf), line: 1, vars: make(Environment)}
+
+	for {
+		p.skipBlank()
+
+		if p.eof() {
+			return p.vars, nil
+		}
+
+		if err := p.parseAssignment(); err != nil {
+			return nil, err
+		}
+	}
+}
+
+type parser struct {
+	src  string
+	pos  int
+	line int
+
+	vars Environment
+}
+
+func (p *parser) eof() bool { return p.pos >= len(p.src) }
+
+func (p *parser) peek() byte { return p.src[p.pos] }
+
+func (p *parser) next() byte {
+	c := p.src[p.pos]
+	p.pos++
+
+	if c == '\n' {
+		p.line++
+	}
+
+	return c
+}
+
+func (p *parser) errorf(msg string, args ...interface{}) error {
+	return errors.Newf("dotenv: line %d: "+msg, append([]interface{}{p.line}, args...)...)
+}
+
+func (p *parser) skipSpaces() {
+	for !p.eof() && (p.peek() == ' ' || p.peek() == '\t') {
+		p.next()
+	}
+}
+
+func (p *parser) skipLine() {
+	for !p.eof() && p.peek() != '\n' {
+		p.next()
+	}
+}
+
+func (p *parser) skipBlank() {
+	for !p.eof() {
+		switch p.peek() {
+		case ' ', '\t', '\r', '\n':
+			p.next()
+		case '#':
+			p.skipLine()
+		default:
+			return
+		}
+	}
+}
+
+func isKeyChar(c byte) bool {
+	return c == '_' || c == '.' || c == '-' ||
+		(c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9')
+}
+
+func (p *parser) readKey() string {
+	start := p.pos
+
+	for !p.eof() && isKeyChar(p.peek()) {
+		p.next()
+	}
+
+	return p.src[start:p.pos]
+}
+
+func (p *parser) parseAssignment() error {
+	key := p.readKey()
+
+	if key == "export" && !p.eof() && (p.peek() == ' ' || p.peek() == '\t') {
+		p.skipSpaces()
+		key = p.readKey()
+	}
+
+	if key == "" {
+		return p.errorf("invalid variable name")
+	}
+
+	p.skipSpaces()
+
+	if p.eof() || p.peek() != '=' {
+		return p.errorf("expected '=' after %q", key)
+	}
+
+	p.next()
+	p.skipSpaces()
+
+	var (
+		v   string
+		err error
+	)
+
+	switch {
+	case p.eof():
+	case p.peek() == '"':
+		v, err = p.parseDoubleQuoted()
+	case p.peek() == '\'':
+		v, err = p.parseSingleQuoted()
+	default:
+		v, err = p.parseUnquoted()
+	}
+
+	if err != nil {
+		return err
+	}
+
+	p.vars[key] = v
+
+	return nil
+}
+
+func (p *parser) endQuotedValue() error {
+	p.skipSpaces()
+
+	if p.eof() || p.peek() == '\n' || p.peek() == '\r' {
+		return nil
+	}
+
+	if p.peek() != '#' {
+		return p.errorf("unexpected character %q after quoted value", p.peek())
+	}
+
+	p.skipLine()
+
+	return nil
+}
+
+func (p *parser) parseSingleQuoted() (string, error) {
+	line := p.line
+	p.next()
+
+	start := p.pos
+
+	for !p.eof() && p.peek() != '\'' {
+		p.next()
+	}
+
+	if p.eof() {
+		p.line = line
+
+		return "", p.errorf("unterminated single quoted value")
+	}
+
+	v := p.src[start:p.pos]
+	p.next()
+
+	return v, p.endQuotedValue()
+}
+
+var escapes = map[byte]byte{
+	'n':  '\n',
+	'r':  '\r',
+	't':  '\t',
+	'"':  '"',
+	'\\': '\\',
+	'$':  '$',
+}
+
+func (p *parser) parseDoubleQuoted() (string, error) {
+	var (
+		b strings.Builder
+
+		line = p.line
+	)
+
+	p.next()
+
+	for !p.eof() {
+		switch c := p.next(); c {
+		case '"':
+			return b.String(), p.endQuotedValue()
+		case '\\':
+			if p.eof() {
+				continue
+			}
+
+			if r, ok := escapes[p.peek()]; ok {
+				b.WriteByte(r)
+				p.next()
+
+				continue
+			}
+
+			b.WriteByte(c)
+		case '$':
+			if err := p.expandReference(&b); err != nil {
+				return "", err
+			}
+		default:
+			b.WriteByte(c)
+		}
+	}
+
+	p.line = line
+
+	return "", p.errorf("unterminated double quoted value")
+}
+
+func (p *parser) parseUnquoted() (string, error) {
+	var b strings.Builder
+
+	for !p.eof() && p.peek() != '\n' {
+		c := p.next()
+
+		switch {
+		case c == '#' && (b.Len() == 0 || isSpace(p.src[p.pos-2])):
+			p.skipLine()
+		case c == '$':
+			if err := p.expandReference(&b); err != nil {
+				return "", err
+			}
+		default:
+			b.WriteByte(c)
+		}
+	}
+
+	return strings.TrimRight(b.String(), " \t\r"), nil
+}
+
+func isSpace(c byte) bool { return c == ' ' || c == '\t' }
+
+func (p *parser) expandReference(b *strings.Builder) error {
+	if p.eof() || p.peek() != '{' {
+		b.WriteByte('$')
+
+		return nil
+	}
+
+	p.next()
+
+	end := strings.IndexByte(p.src[p.pos:], '}')
+
+	if end < 0 {
+		return p.errorf("unterminated variable reference")
+	}
+
+	name := p.src[p.pos : p.pos+end]
+
+	for i := 0; i <= end; i++ {
+		p.next()
+	}
+
+	b.WriteString(p.lookup(name))
+
+	return nil
+}
+
+func (p *parser) lookup(name string) string {
+	if v, ok := p.vars[name]; ok {
+		return v
+	}
+
+	return os.Getenv(name)
+}
@@ -0,0 +1,98 @@
+package dotenv
+
+import (
+	"context"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/upfluence/errors"
+
+	"github.com/upfluence/cfg/provider"
+	"github.com/upfluence/cfg/provider/env"
+)
+
+const DefaultPath = ".env"
+
+// NewProvider returns a provider serving the variables of the dotenv file
+// read from r.  It shares the env struct tag, key and prefix rules of the
+// env provider, so the same struct can be populated from either source.
+func NewProvider(prefix string, r io.Reader) provider.Provider {
+	e, err := Parse(r)
+
+	if err != nil {
+		return provider.ProvideError(env.StructTag, err)
+	}
+
+	return newProvider(prefix, e)
+}
+
+// Provider serves the variables of a dotenv file, with the rules of the
+// env provider.  Unlike the process environment, the file only holds
+// variables of the app, so it lists its keys.
+type Provider struct {
+	*env.Provider
+
+	prefix string
+	vars   Environment
+}
+
+func newProvider(prefix string, e Environment) *Provider {
+	return &Provider{
+		Provider: env.NewProviderFromEnvironment(prefix, e),
+		prefix:   prefix,
+		vars:     e,
+	}
+}
+
+// Keys returns the variables of the file starting with the prefix of the
+// provider, stripped of it.
+func (p *Provider) Keys(context.Context) ([]string, error) {
+	var (
+		keys   []string
+		prefix string
+	)
+
+	if p.prefix != "" {
+		prefix = strings.ToUpper(p.prefix) + "_"
+	}
+
+	for k := range p.vars {
+		if strings.HasPrefix(k, prefix) && len(k) > len(prefix) {
+			keys = append(keys, k[len(prefix):])
+		}
+	}
+
+	sort.Strings(keys)
+
+	return keys, nil
+}
+
+func NewProviderFromReader(r io.Reader) provider.Provider {
+	return NewProvider("", r)
+}
+
+func NewProviderFromFile(path string) provider.Provider {
+	f, err := os.Open(path)
+
+	if err != nil {
+		return provider.ProvideError(env.StructTag, err)
+	}
+
+	defer f.Close()
+
+	return NewProviderFromReader(f)
+}
+
+// NewDefaultProvider reads the .env file of the working directory.  A
+// missing file results in a provider serving no value.
+func NewDefaultProvider() provider.Provider {
+	p := NewProviderFromFile(DefaultPath)
+
+	if fp, ok := p.(interface{ Err() error }); ok && errors.Is(fp.Err(), os.ErrNotExist) {
+		return newProvider("", Environment{})
+	}
+
+	return p
+}
@@ -0,0 +1,195 @@
+package dotenv
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/upfluence/cfg"
+	"github.com/upfluence/cfg/provider"
+	"github.com/upfluence/cfg/provider/env"
+)
+
+func TestParse(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		haveEnv map[string]string
+		have    string
+		want    Environment
+		wantErr string
+	}{
+		{
+			name: "empty",
+			have: "",
+			want: Environment{},
+		},
+		{
+			name: "unquoted values and comments",
+			have: "# comment\nFOO=bar\n\nBUZ = biz # trailing comment\nURL=http://x/#anchor\n",
+			want: Environment{"FOO": "bar", "BUZ": "biz", "URL": "http://x/#anchor"},
+		},
+		{
+			name: "export prefix",
+			have: "export FOO=bar\nexport\tBAR='buz'\n",
+			want: Environment{"FOO": "bar", "BAR": "buz"},
+		},
+		{
+			name: "empty value",
+			have: "FOO=\nBAR=''\n",
+			want: Environment{"FOO": "", "BAR": ""},
+		},
+		{
+			name: "single quoted values are literal",
+			have: `FOO='${BAR} \n # not a comment'`,
+			want: Environment{"FOO": `${BAR} \n # not a comment`},
+		},
+		{
+			name: "double quoted escapes",
+			have: `FOO="a\tb\n\"c\" \\ \$ \x" # comment`,
+			want: Environment{"FOO": "a\tb\n\"c\" \\ $ \\x"},
+		},
+		{
+			name: "multi-line double quoted value",
+			have: "KEY=\"-----BEGIN-----\nabc\n-----END-----\"\nFOO=bar\n",
+			want: Environment{"KEY": "-----BEGIN-----\nabc\n-----END-----", "FOO": "bar"},
+		},
+		{
+			name:    "interpolation",
+			haveEnv: map[string]string{"DOTENV_TEST_HOST": "db.local"},
+			have:    "USER=admin\nURL=\"postgres://${USER}@${DOTENV_TEST_HOST}/app\"\nRAW=${USER}-$USER\nMISSING=x${DOTENV_TEST_MISSING}x\n",
+			want: Environment{
+				"USER":    "admin",
+				"URL":     "postgres://admin@db.local/app",
+				"RAW":     "admin-$USER",
+				"MISSING": "xx",
+			},
+		},
+		{
+			name:    "missing equal sign",
+			have:    "FOO=bar\nBUZ\n",
+			wantErr: `dotenv: line 2: expected '=' after "BUZ"`,
+		},
+		{
+			name:    "invalid name",
+			have:    "=bar\n",
+			wantErr: "dotenv: line 1: invalid variable name",
+		},
+		{
+			name:    "unterminated double quote",
+			have:    "FOO=bar\nBUZ=\"biz\n\n",
+			wantErr: "dotenv: line 2: unterminated double quoted value",
+		},
+		{
+			name:    "unterminated reference",
+			have:    "FOO=${BAR\n",
+			wantErr: "dotenv: line 1: unterminated variable reference",
+		},
+		{
+			name:    "garbage after quoted value",
+			have:    "FOO='bar' buz\n",
+			wantErr: `dotenv: line 1: unexpected character 'b' after quoted value`,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			for k, v := range tc.haveEnv {
+				t.Setenv(k, v)
+			}
+
+			got, err := Parse(strings.NewReader(tc.have))
+
+			if tc.wantErr != "" {
+				require.EqualError(t, err, tc.wantErr)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+type dbConfig struct {
+	Host string
+	Port int
+}
+
+type populateConfig struct {
+	Name      string
+	Databases map[string]dbConfig
+	Workers   []dbConfig
+}
+
+func TestPopulate(t *testing.T) {
+	var (
+		c populateConfig
+
+		p = NewProvider(
+			"app",
+			strings.NewReader(`
+APP_NAME=svc
+APP_DATABASES_PRIMARY_HOST=h1
+APP_DATABASES_PRIMARY_PORT=5432
+APP_WORKERS_0_HOST=w0
+APP_WORKERS_1_HOST=w1
+`),
+		)
+	)
+
+	err := cfg.NewConfigurator(p).Populate(context.Background(), &c)
+
+	require.NoError(t, err)
+	assert.Equal(
+		t,
+		populateConfig{
+			Name:      "svc",
+			Databases: map[string]dbConfig{"PRIMARY": {Host: "h1", Port: 5432}},
+			Workers:   []dbConfig{{Host: "w0"}, {Host: "w1"}},
+		},
+		c,
+	)
+	assert.Equal(t, env.StructTag, p.StructTag())
+}
+
+func TestNewProviderFromFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".env")
+
+	require.NoError(t, os.WriteFile(path, []byte("FOO=bar\n"), 0o600))
+
+	v, ok, err := NewProviderFromFile(path).Provide(context.Background(), "FOO")
+
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, "bar", v)
+
+	_, _, err = NewProviderFromFile(path+".missing").Provide(context.Background(), "FOO")
+
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestProvider_Keys(t *testing.T) {
+	p := NewProvider("app", strings.NewReader("APP_NAME=svc\nAPP_DB_HOST=h\nOTHER=x\n"))
+
+	kl, ok := p.(provider.KeyLister)
+
+	require.True(t, ok)
+
+	ks, err := kl.Keys(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"DB_HOST", "NAME"}, ks)
+
+	var c populateConfig
+
+	err = cfg.NewConfigurator(p).WithOptions(cfg.DisallowUnusedKeys).Populate(
+		context.Background(),
+		&c,
+	)
+
+	assert.EqualError(t, err, "unused key env: APP_DB_HOST")
+}
@@ -6,8 +6,23 @@ import (
 	"strings"
 )
 
+const StructTag = "env"
+
+// Environment is the source of variables read by the Provider.  The
+// process environment is used by default.
+type Environment interface {
+	LookupEnv(string) (string, bool)
+	Environ() []string
+}
+
+type osEnvironment struct{}
+
+func (osEnvironment) LookupEnv(k string) (string, bool) { return os.LookupEnv(k) }
+func (osEnvironment) Environ() []string                 { return os.Environ() }
+
 type Provider struct {
 	prefix string
+	env    Environment
 }
 
 func NewProvider(p string) *Provider {
@@ -18,7 +33,22 @@ func NewDefaultProvider() *Provider {
 	return &Provider{}
 }
 
-func (*Provider) StructTag() string { return "env" }
+// NewProviderFromEnvironment returns a Provider reading its variables
+// from e instead of the process environment, with the same key and
+// prefix rules.
+func NewProviderFromEnvironment(p string, e Environment) *Provider {
+	return &Provider{prefix: p, env: e}
+}
+
+func (p *Provider) environment() Environment {
+	if p.env == nil {
+		return osEnvironment{}
+	}
+
+	return p.env
+}
+
+func (*Provider) StructTag() string { return StructTag }
 
 func (p *Provider) buildPrefix() string {
 	if p.prefix == "" {
@@ -41,7 +71,7 @@ func (p *Provider) FormatKey(n string) string {
 }
 
 func (p *Provider) Provide(_ context.Context, v string) (string, bool, error) {
-	res, ok := os.LookupEnv(p.buildPrefix() + v)
+	res, ok := p.environment().LookupEnv(p.buildPrefix() + v)
 
 	return res, ok, nil
 }
@@ -51,7 +81,7 @@ func (p *Provider) SubKeys(_ context.Context, prefix string) ([]string, error) {
 
 	seen := make(map[string]struct{})
 
-	for _, entry := range os.Environ() {
+	for _, entry := range p.environment().Environ() {
 		if !strings.HasPrefix(entry, fullPrefix) {
 			continue
 		}
@@ -150,3 +150,39 @@ func TestProvider_SubKeys(t *testing.T) {
 		})
 	}
 }
+
+type staticEnvironment map[string]string
+
+func (se staticEnvironment) LookupEnv(k string) (string, bool) {
+	v, ok := se[k]
+
+	return v, ok
+}
+
+func (se staticEnvironment) Environ() []string {
+	var res []string
+
+	for k, v := range se {
+		res = append(res, k+"="+v)
+	}
+
+	return res
+}
+
+func TestNewProviderFromEnvironment(t *testing.T) {
+	p := NewProviderFromEnvironment(
+		"app",
+		staticEnvironment{"APP_FOO": "bar", "APP_DB_PRIMARY_HOST": "h1"},
+	)
+
+	v, ok, err := p.Provide(context.Background(), "FOO")
+
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, "bar", v)
+
+	ks, err := p.SubKeys(context.Background(), "DB")
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"PRIMARY"}, ks)
+}
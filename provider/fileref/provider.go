@@ -0,0 +1,161 @@
+package fileref
+
+import (
+	"context"
+	"os"
+	"strings"
+
+	"github.com/upfluence/errors"
+
+	"github.com/upfluence/cfg/provider"
+)
+
+const (
+	Scheme = "file://"
+
+	defaultKeySuffix = "File"
+)
+
+type Option func(*Provider)
+
+// WithKeySuffix changes the suffix of the fallback key holding the path of
+// the file, "File" by default (FOO_FILE for the env provider).
+func WithKeySuffix(s string) Option {
+	return func(p *Provider) { p.suffix = s }
+}
+
+// Provider resolves the file references of the provider it wraps, following
+// the conventions used to mount Docker and Kubernetes secrets.  A value of
+// the form file:///run/secrets/foo is replaced by the content of the file.
+// When the key itself is not provided, the key suffixed with "File" (e.g.
+// FOO_FILE for FOO) is looked up and its value used as the file path.  The
+// trailing newline of the file content is trimmed.
+//
+// The provider.Watcher, provider.KeyLister and provider.MultiValueProvider
+// interfaces are forwarded to the wrapped provider when it implements
+// them.
+type Provider struct {
+	provider.FullyQualifiedProvider
+
+	p      provider.Provider
+	suffix string
+}
+
+func NewProvider(p provider.Provider, opts ...Option) *Provider {
+	fp := Provider{
+		FullyQualifiedProvider: provider.WrapFullyQualifiedProvider(p),
+		p:                      p,
+		suffix:                 defaultKeySuffix,
+	}
+
+	for _, opt := range opts {
+		opt(&fp)
+	}
+
+	return &fp
+}
+
+func (p *Provider) FormatKey(k string) string {
+	if kf, ok := p.p.(provider.KeyFormatter); ok {
+		return kf.FormatKey(k)
+	}
+
+	return k
+}
+
+func (p *Provider) Provide(ctx context.Context, k string) (string, bool, error) {
+	v, ok, err := p.p.Provide(ctx, k)
+
+	if err != nil {
+		return "", false, err
+	}
+
+	if ok {
+		if !strings.HasPrefix(v, Scheme) {
+			return v, true, nil
+		}
+
+		return readFile(strings.TrimPrefix(v, Scheme))
+	}
+
+	if p.suffix == "" {
+		return "", false, nil
+	}
+
+	v, ok, err = p.p.Provide(
+		ctx,
+		p.JoinFieldKeys(k, p.DefaultFieldValue(p.suffix)),
+	)
+
+	if err != nil || !ok {
+		return "", false, err
+	}
+
+	return readFile(strings.TrimPrefix(v, Scheme))
+}
+
+// ProvideValues resolves the file references of each value given to the
+// key by the wrapped provider, or of the single value it provides when it
+// is not a provider.MultiValueProvider.
+func (p *Provider) ProvideValues(ctx context.Context, k string) ([]string, bool, error) {
+	mvp, ok := p.p.(provider.MultiValueProvider)
+
+	if !ok {
+		v, ok, err := p.Provide(ctx, k)
+
+		return []string{v}, ok, err
+	}
+
+	vs, ok, err := mvp.ProvideValues(ctx, k)
+
+	if err != nil || !ok {
+		return nil, ok, err
+	}
+
+	res := make([]string, len(vs))
+
+	for i, v := range vs {
+		if !strings.HasPrefix(v, Scheme) {
+			res[i] = v
+			continue
+		}
+
+		if res[i], _, err = readFile(strings.TrimPrefix(v, Scheme)); err != nil {
+			return nil, false, err
+		}
+	}
+
+	return res, true, nil
+}
+
+// Watch returns the notifications of the wrapped provider, or a nil
+// channel when it can not be watched.
+func (p *Provider) Watch(ctx context.Context) <-chan struct{} {
+	if w, ok := p.p.(provider.Watcher); ok {
+		return w.Watch(ctx)
+	}
+
+	return nil
+}
+
+// Keys returns the keys of the wrapped provider, none being returned when
+// it does not list them.
+func (p *Provider) Keys(ctx context.Context) ([]string, error) {
+	if kl, ok := p.p.(provider.KeyLister); ok {
+		return kl.Keys(ctx)
+	}
+
+	return nil, nil
+}
+
+func readFile(path string) (string, bool, error) {
+	buf, err := os.ReadFile(path)
+
+	if err != nil {
+		return "", false, errors.Wrapf(err, "read %q", path)
+	}
+
+	v := strings.TrimSuffix(string(buf), "\n")
+
+	return strings.TrimSuffix(v, "\r"), true, nil
+}
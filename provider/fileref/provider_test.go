@@ -0,0 +1,210 @@
+package fileref
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/upfluence/cfg"
+	"github.com/upfluence/cfg/provider"
+	"github.com/upfluence/cfg/provider/env"
+	"github.com/upfluence/cfg/provider/flags"
+	"github.com/upfluence/cfg/provider/json"
+)
+
+type mapEnvironment map[string]string
+
+func (e mapEnvironment) LookupEnv(k string) (string, bool) {
+	v, ok := e[k]
+	return v, ok
+}
+
+func (e mapEnvironment) Environ() []string { return nil }
+
+func TestProvider_Provide(t *testing.T) {
+	var (
+		dir     = t.TempDir()
+		secret  = filepath.Join(dir, "secret")
+		crlf    = filepath.Join(dir, "crlf")
+		missing = filepath.Join(dir, "missing")
+	)
+
+	require.NoError(t, os.WriteFile(secret, []byte("hunter2\n"), 0600))
+	require.NoError(t, os.WriteFile(crlf, []byte("line\r\n"), 0600))
+
+	for _, tc := range []struct {
+		name string
+		env  map[string]string
+		opts []Option
+		key  string
+
+		wantValue string
+		wantOK    bool
+		wantErr   bool
+	}{
+		{name: "not provided", key: "FOO"},
+		{
+			name:      "plain value",
+			env:       map[string]string{"FOO": "bar"},
+			key:       "FOO",
+			wantValue: "bar",
+			wantOK:    true,
+		},
+		{
+			name:      "file url",
+			env:       map[string]string{"FOO": "file://" + secret},
+			key:       "FOO",
+			wantValue: "hunter2",
+			wantOK:    true,
+		},
+		{
+			name:      "file key",
+			env:       map[string]string{"FOO_FILE": secret},
+			key:       "FOO",
+			wantValue: "hunter2",
+			wantOK:    true,
+		},
+		{
+			name:      "value wins over file key",
+			env:       map[string]string{"FOO": "bar", "FOO_FILE": secret},
+			key:       "FOO",
+			wantValue: "bar",
+			wantOK:    true,
+		},
+		{
+			name:      "custom suffix",
+			env:       map[string]string{"FOO_PATH": crlf},
+			opts:      []Option{WithKeySuffix("Path")},
+			key:       "FOO",
+			wantValue: "line",
+			wantOK:    true,
+		},
+		{
+			name: "disabled suffix",
+			env:  map[string]string{"FOO_FILE": secret},
+			opts: []Option{WithKeySuffix("")},
+			key:  "FOO",
+		},
+		{
+			name:    "missing file",
+			env:     map[string]string{"FOO_FILE": missing},
+			key:     "FOO",
+			wantErr: true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			p := NewProvider(
+				env.NewProviderFromEnvironment("", mapEnvironment(tc.env)),
+				tc.opts...,
+			)
+
+			v, ok, err := p.Provide(context.Background(), tc.key)
+
+			assert.Equal(t, tc.wantErr, err != nil)
+			assert.Equal(t, tc.wantValue, v)
+			assert.Equal(t, tc.wantOK, ok)
+		})
+	}
+}
+
+func TestProvider_Delegation(t *testing.T) {
+	p := NewProvider(env.NewProvider("app"))
+
+	assert.Equal(t, env.StructTag, p.StructTag())
+	assert.Equal(t, "APP_FOO", p.FormatKey("FOO"))
+	assert.Equal(t, "FOO_BAR", p.JoinFieldKeys("FOO", p.DefaultFieldValue("bar")))
+
+	sp := NewProvider(provider.NewStaticProvider("static", nil, nil))
+
+	assert.Equal(t, "foo", sp.FormatKey("foo"))
+}
+
+func TestPopulate(t *testing.T) {
+	var (
+		c struct {
+			Password string `env:"PASSWORD"`
+		}
+
+		missing = filepath.Join(t.TempDir(), "missing")
+	)
+
+	err := cfg.NewConfigurator(
+		NewProvider(
+			env.NewProviderFromEnvironment(
+				"",
+				mapEnvironment{"PASSWORD": "file://" + missing},
+			),
+		),
+	).Populate(context.Background(), &c)
+
+	var pe *cfg.ProvidingError
+
+	require.ErrorAs(t, err, &pe)
+	assert.Equal(t, "PASSWORD", pe.Key)
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestProvider_OptionalInterfaces(t *testing.T) {
+	var (
+		dir    = t.TempDir()
+		path   = filepath.Join(dir, "config.json")
+		secret = filepath.Join(dir, "secret")
+		now    = time.Now()
+
+		write = func(content string, mtime time.Time) {
+			require.NoError(t, os.WriteFile(path, []byte(content), 0600))
+			require.NoError(t, os.Chtimes(path, mtime, mtime))
+		}
+	)
+
+	require.NoError(t, os.WriteFile(secret, []byte("s3cr3t\n"), 0600))
+	write(`{"password":"file://`+secret+`","extra":1}`, now.Add(-time.Hour))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	p := NewProvider(json.NewProviderFromFile(path, json.WithPollInterval(5*time.Millisecond)))
+
+	ks, err := p.Keys(ctx)
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"extra", "password"}, ks)
+
+	var c struct {
+		Password string `json:"password"`
+	}
+
+	err = cfg.NewConfigurator(p).WithOptions(cfg.DisallowUnusedKeys).Populate(ctx, &c)
+
+	assert.EqualError(t, err, "unused key json: extra")
+	assert.Equal(t, "s3cr3t", c.Password)
+
+	ch, err := cfg.Watch(ctx, cfg.NewConfigurator(p), &c)
+	require.NoError(t, err)
+
+	write(`{"password":"plain"}`, now)
+
+	select {
+	case u := <-ch:
+		require.NoError(t, u.Err)
+		assert.Equal(t, []string{"Password"}, u.Changed)
+	case <-time.After(time.Second):
+		t.Fatal("no update delivered")
+	}
+
+	_, err = cfg.Watch(ctx, cfg.NewConfigurator(NewProvider(env.NewProvider("app"))), &c)
+	assert.Equal(t, cfg.ErrWatchNotSupported, err)
+
+	vs, ok, err := NewProvider(
+		flags.NewProvider([]string{"--tag", "a", "--tag", "file://" + secret}),
+	).ProvideValues(ctx, "tag")
+
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, []string{"a", "s3cr3t"}, vs)
+}
@@ -3,6 +3,7 @@ package flags
 import (
 	"context"
 	"os"
+	"sort"
 	"strconv"
 	"strings"
 	"unicode"
@@ -28,8 +29,29 @@ func parseArg(s string) (string, bool) {
 }
 
 func parseFlags(args []string) map[string]string {
+	return lastValues(parseFlagValues(args))
+}
+
+func lastValues(vss map[string][]string) map[string]string {
+	res := make(map[string]string, len(vss))
+
+	for k, vs := range vss {
+		res[k] = vs[len(vs)-1]
+	}
+
+	return res
+}
+
+// parseFlagValues returns every value given to each flag, in order.
+func parseFlagValues(args []string) map[string][]string {
+	return parseFoldedFlagValues(args, func(k string) string { return k })
+}
+
+// parseFoldedFlagValues is parseFlagValues with the flag names mapped by
+// kfn, the values of the names mapped to the same key being merged.
+func parseFoldedFlagValues(args []string, kfn func(string) string) map[string][]string {
 	var (
-		res = make(map[string]string)
+		res = make(map[string][]string)
 
 		key     string
 		inParam bool
@@ -41,10 +63,6 @@ func parseFlags(args []string) map[string]string {
 
 			switch vs, _ := stringutil.Split(v, '='); len(vs) {
 			case 2:
-				if inParam {
-					res[key] = val
-				}
-
 				inParam = false
 				key = vs[0]
 				val = vs[1]
@@ -63,9 +81,10 @@ func parseFlags(args []string) map[string]string {
 				}
 			}
 
-			res[key] = val
+			key = kfn(key)
+			res[key] = append(res[key], val)
 		} else if len(v) > 0 && inParam {
-			res[key] = v
+			res[key][len(res[key])-1] = v
 			inParam = false
 		}
 	}
@@ -78,21 +97,25 @@ func NewDefaultProvider() *Provider {
 }
 
 func NewProvider(args []string) *Provider {
-	fs := parseFlags(args)
+	// The flags are read case-insensitively, their names are kept as given
+	// for Names and SubKeys.
+	values := parseFoldedFlagValues(args, strings.ToLower)
 
 	return &Provider{
-		flags: fs,
+		flags:  parseFlags(args),
+		values: values,
 		sp: provider.NewStaticProvider(
 			StructTag,
-			fs,
+			lastValues(values),
 			strings.ToLower,
 		),
 	}
 }
 
 type Provider struct {
-	flags map[string]string
-	sp    provider.Provider
+	flags  map[string]string
+	values map[string][]string
+	sp     provider.Provider
 }
 
 func kebabCase(s string) string {
@@ -131,7 +154,7 @@ func (p *Provider) SubKeys(_ context.Context, prefix string) ([]string, error) {
 	seen := make(map[string]struct{})
 
 	for k := range p.flags {
-		if !strings.HasPrefix(k, fullPrefix) {
+		if len(k) < len(fullPrefix) || !strings.EqualFold(k[:len(fullPrefix)], fullPrefix) {
 			continue
 		}
 
@@ -157,6 +180,24 @@ func (p *Provider) SubKeys(_ context.Context, prefix string) ([]string, error) {
 	return keys, nil
 }
 
+// Names returns the names of the flags, as given on the command line.  As
+// the flags are read case-insensitively, they are compared lowercased.
+//
+// The provider does not implement provider.KeyLister: the command line
+// holds flags read by other structs, such as --help, that the unused keys
+// check would report.
+func (p *Provider) Names() []string {
+	names := make([]string, 0, len(p.flags))
+
+	for k := range p.flags {
+		names = append(names, k)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
 func (*Provider) FormatKey(n string) string {
 	n = strings.ToLower(n)
 
@@ -170,3 +211,11 @@ func (*Provider) FormatKey(n string) string {
 func (p *Provider) Provide(ctx context.Context, k string) (string, bool, error) {
 	return p.sp.Provide(ctx, k)
 }
+
+// ProvideValues returns every value of a repeated flag, e.g. "a" and "b"
+// for `--tag a --tag b`.
+func (p *Provider) ProvideValues(_ context.Context, k string) ([]string, bool, error) {
+	vs, ok := p.values[strings.ToLower(k)]
+
+	return vs, ok, nil
+}
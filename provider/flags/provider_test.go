@@ -7,6 +7,8 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/upfluence/cfg/provider"
 )
 
 func TestParseFlags(t *testing.T) {
@@ -108,6 +110,55 @@ func TestParseFlags(t *testing.T) {
 	}
 }
 
+func TestParseFlagValues(t *testing.T) {
+	assert.Equal(
+		t,
+		map[string][]string{
+			"tag":  {"a", "b,c", "d"},
+			"foo":  {"true", "false"},
+			"name": {"bar"},
+		},
+		parseFlagValues(
+			[]string{"--tag", "a", "--foo", "--tag=b,c", "--name", "bar", "-tag", "d", "--no-foo"},
+		),
+	)
+}
+
+func TestProvideValues(t *testing.T) {
+	p := NewProvider([]string{"--tag", "a", "--tag", "b"})
+
+	v, ok, err := p.Provide(context.Background(), "TAG")
+
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, "b", v)
+
+	vs, ok, err := p.ProvideValues(context.Background(), "TAG")
+
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, []string{"a", "b"}, vs)
+
+	p = NewProvider([]string{"--Tag", "a", "--TAG=b"})
+
+	v, ok, err = p.Provide(context.Background(), "tag")
+
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, "b", v)
+
+	vs, ok, err = p.ProvideValues(context.Background(), "tag")
+
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, []string{"a", "b"}, vs)
+
+	_, ok, err = p.ProvideValues(context.Background(), "missing")
+
+	require.NoError(t, err)
+	assert.False(t, ok)
+}
+
 func TestKebabCase(t *testing.T) {
 	for _, tc := range []struct {
 		name      string
@@ -164,6 +215,12 @@ func TestProvider_SubKeys(t *testing.T) {
 			haveKey: "Workers",
 			want:    []string{"PRIMARY"},
 		},
+		{
+			name:     "matches the prefix case-insensitively",
+			haveArgs: []string{"--Workers.PRIMARY.Host", "h1"},
+			haveKey:  "workers",
+			want:     []string{"PRIMARY"},
+		},
 		{
 			name:     "ignores exact prefix without sub-segment",
 			haveArgs: []string{"--workers=true"},
@@ -184,3 +241,12 @@ func TestProvider_SubKeys(t *testing.T) {
 		})
 	}
 }
+
+func TestProvider_Names(t *testing.T) {
+	p := NewProvider([]string{"--port", "80", "-v", "--no-debug", "--DB.Host=h"})
+
+	assert.Equal(t, []string{"DB.Host", "debug", "port", "v"}, p.Names())
+
+	_, ok := interface{}(p).(provider.KeyLister)
+	assert.False(t, ok)
+}
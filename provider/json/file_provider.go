@@ -0,0 +1,158 @@
+package json
+
+import (
+	"context"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/upfluence/cfg/provider"
+)
+
+const defaultPollInterval = time.Second
+
+type FileOption func(*FileProvider)
+
+func WithPollInterval(d time.Duration) FileOption {
+	return func(fp *FileProvider) { fp.interval = d }
+}
+
+type fileState struct {
+	modTime time.Time
+	size    int64
+	exists  bool
+}
+
+// FileProvider serves the content of a JSON file.  It implements
+// provider.Watcher by polling the modification time of the file and
+// reloading it when it changed.  If the file can no longer be read,
+// Provide returns the read error until it becomes valid again.
+type FileProvider struct {
+	path     string
+	interval time.Duration
+
+	mu    sync.RWMutex
+	p     provider.Provider
+	state fileState
+}
+
+func NewProviderFromFile(path string, opts ...FileOption) *FileProvider {
+	fp := FileProvider{path: path, interval: defaultPollInterval}
+
+	for _, opt := range opts {
+		opt(&fp)
+	}
+
+	fp.p, fp.state = fp.load()
+
+	return &fp
+}
+
+func (fp *FileProvider) stat() fileState {
+	fi, err := os.Stat(fp.path)
+
+	if err != nil {
+		return fileState{}
+	}
+
+	return fileState{modTime: fi.ModTime(), size: fi.Size(), exists: true}
+}
+
+func (fp *FileProvider) load() (provider.Provider, fileState) {
+	st := fp.stat()
+
+	f, err := os.Open(fp.path)
+
+	if err != nil {
+		return provider.ProvideError(fp.StructTag(), err), st
+	}
+
+	defer f.Close()
+
+	return NewProviderFromReader(f), st
+}
+
+func (fp *FileProvider) current() provider.Provider {
+	fp.mu.RLock()
+	defer fp.mu.RUnlock()
+
+	return fp.p
+}
+
+func (*FileProvider) StructTag() string { return "json" }
+
+func (*FileProvider) DefaultFieldValue(fieldName string) string {
+	return fieldName
+}
+
+func (*FileProvider) JoinFieldKeys(prefix, key string) string {
+	return prefix + "." + key
+}
+
+func (fp *FileProvider) Provide(ctx context.Context, k string) (string, bool, error) {
+	return fp.current().Provide(ctx, k)
+}
+
+func (fp *FileProvider) SubKeys(ctx context.Context, prefix string) ([]string, error) {
+	if p, ok := fp.current().(*Provider); ok {
+		return p.SubKeys(ctx, prefix)
+	}
+
+	return nil, nil
+}
+
+func (fp *FileProvider) reload() bool {
+	fp.mu.RLock()
+	prev := fp.state
+	fp.mu.RUnlock()
+
+	if fp.stat() == prev {
+		return false
+	}
+
+	p, st := fp.load()
+
+	fp.mu.Lock()
+	fp.p, fp.state = p, st
+	fp.mu.Unlock()
+
+	return true
+}
+
+func (fp *FileProvider) Watch(ctx context.Context) <-chan struct{} {
+	ch := make(chan struct{}, 1)
+
+	go func() {
+		defer close(ch)
+
+		t := time.NewTicker(fp.interval)
+		defer t.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-t.C:
+			}
+
+			if !fp.reload() {
+				continue
+			}
+
+			select {
+			case ch <- struct{}{}:
+			default:
+			}
+		}
+	}()
+
+	return ch
+}
+
+func (fp *FileProvider) Keys(ctx context.Context) ([]string, error) {
+	if p, ok := fp.current().(*Provider); ok {
+		return p.Keys(ctx)
+	}
+
+	return nil, nil
+}
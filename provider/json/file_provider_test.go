@@ -0,0 +1,79 @@
+package json
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeFile(t *testing.T, path, content string, mtime time.Time) {
+	t.Helper()
+
+	require.NoError(t, os.WriteFile(path, []byte(content), 0600))
+	require.NoError(t, os.Chtimes(path, mtime, mtime))
+}
+
+func TestFileProvider_Provide(t *testing.T) {
+	var (
+		ctx  = context.Background()
+		path = filepath.Join(t.TempDir(), "config.json")
+	)
+
+	p := NewProviderFromFile(path)
+
+	_, _, err := p.Provide(ctx, "foo")
+	assert.Error(t, err)
+
+	writeFile(t, path, `{"foo":{"bar":"buz"}}`, time.Now())
+
+	assert.True(t, p.reload())
+	assert.False(t, p.reload())
+
+	v, ok, err := p.Provide(ctx, "foo.bar")
+
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, "buz", v)
+
+	ks, err := p.SubKeys(ctx, "foo")
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"bar"}, ks)
+}
+
+func TestFileProvider_Watch(t *testing.T) {
+	var (
+		path = filepath.Join(t.TempDir(), "config.json")
+		now  = time.Now()
+	)
+
+	writeFile(t, path, `{"foo":"bar"}`, now.Add(-time.Hour))
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	p := NewProviderFromFile(path, WithPollInterval(5*time.Millisecond))
+	ch := p.Watch(ctx)
+
+	writeFile(t, path, `{"foo":"buz"}`, now)
+
+	select {
+	case <-ch:
+	case <-time.After(time.Second):
+		t.Fatal("no change notified")
+	}
+
+	v, _, err := p.Provide(ctx, "foo")
+
+	require.NoError(t, err)
+	assert.Equal(t, "buz", v)
+
+	cancel()
+
+	for range ch {
+	}
+}
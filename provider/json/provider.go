@@ -2,15 +2,13 @@ package json
 
 import (
 	"context"
-	"encoding/csv"
 	"encoding/json"
-	"fmt"
 	"io"
-	"reflect"
-	"strings"
 
 	"github.com/upfluence/errors"
 
+	"github.com/upfluence/cfg/internal/stringutil"
+	"github.com/upfluence/cfg/internal/tree"
 	"github.com/upfluence/cfg/provider"
 )
 
@@ -42,120 +40,33 @@ func NewProviderFromReaderAndDecoder(r io.Reader, fn DecodeFunc) provider.Provid
 
 func (*Provider) StructTag() string { return "json" }
 
-func (p *Provider) Provide(_ context.Context, v string) (string, bool, error) {
-	var (
-		cur         = p.store
-		splittedKey = strings.Split(v, ".")
-
-		res interface{}
-	)
-
-	for i, k := range splittedKey {
-		t := cur[k]
-
-		if t == nil {
-			return "", false, nil
-		}
-
-		if i == len(splittedKey)-1 {
-			res = t
-			continue
-		}
-
-		next, ok := t.(map[string]interface{})
-
-		if !ok {
-			return "", false, ErrJSONMalformated
-		}
-
-		cur = next
-	}
+func (*Provider) DefaultFieldValue(fieldName string) string {
+	return fieldName
+}
 
-	return stringifyValue(res), true, nil
+func (*Provider) JoinFieldKeys(prefix, key string) string {
+	return prefix + "." + key
 }
 
-func (p *Provider) SubKeys(_ context.Context, prefix string) ([]string, error) {
-	cur := p.navigateTo(prefix)
+func (p *Provider) Provide(_ context.Context, k string) (string, bool, error) {
+	v, ok, err := tree.Lookup(p.store, k)
 
-	if cur == nil {
-		return nil, nil
+	if errors.Is(err, tree.ErrMalformated) {
+		return "", false, ErrJSONMalformated
 	}
 
-	keys := make([]string, 0, len(cur))
-
-	for k := range cur {
-		keys = append(keys, k)
+	if err != nil || !ok {
+		return "", false, err
 	}
 
-	return keys, nil
+	return stringutil.Stringify(v), true, nil
 }
 
-func (p *Provider) navigateTo(prefix string) map[string]any {
-	cur := p.store
-
-	for k := range strings.SplitSeq(prefix, ".") {
-		t := cur[k]
-
-		if t == nil {
-			return nil
-		}
-
-		next, ok := t.(map[string]any)
-
-		if !ok {
-			return nil
-		}
-
-		cur = next
-	}
-
-	return cur
+func (p *Provider) SubKeys(_ context.Context, prefix string) ([]string, error) {
+	return tree.SubKeys(p.store, prefix), nil
 }
 
-func stringifyValue(v interface{}) string {
-	vv := reflect.ValueOf(v)
-
-	switch vv.Kind() {
-	case reflect.Slice:
-		var vs []string
-
-		for i := 0; i < vv.Len(); i++ {
-			vs = append(vs, stringifyValue(vv.Index(i).Interface()))
-		}
-
-		var b strings.Builder
-
-		w := csv.NewWriter(&b)
-
-		if err := w.Write(vs); err != nil {
-			return strings.Join(vs, ",")
-		}
-
-		w.Flush()
-
-		if res := b.String(); len(res) > 0 {
-			return res[:len(res)-1]
-		}
-
-		return strings.Join(vs, ",")
-	case reflect.Map:
-		var vs []string
-
-		for _, mkv := range vv.MapKeys() {
-			mvv := vv.MapIndex(mkv)
-
-			vs = append(
-				vs,
-				fmt.Sprintf(
-					"%s=%s",
-					stringifyValue(mkv.Interface()),
-					stringifyValue(mvv.Interface()),
-				),
-			)
-		}
-
-		return strings.Join(vs, ",")
-	}
-
-	return fmt.Sprintf("%v", v)
+// Keys returns the keys of the scalar values of the document.
+func (p *Provider) Keys(context.Context) ([]string, error) {
+	return tree.Keys(p.store), nil
 }
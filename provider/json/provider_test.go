@@ -8,6 +8,8 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/upfluence/cfg"
 )
 
 func TestProvider_Provide(t *testing.T) {
@@ -56,6 +58,13 @@ func TestProvider_Provide(t *testing.T) {
 			wantValue: "bar",
 			wantExist: true,
 		},
+		{
+			name:      "array item",
+			haveJSON:  `{"foo":[{"fiz":"bar"},{"fiz":"buz"}]}`,
+			haveKey:   "foo.1.fiz",
+			wantValue: "buz",
+			wantExist: true,
+		},
 		{
 			name:     "wrong format",
 			haveJSON: `{"foo":{"fiz":"bar"}}`,
@@ -112,6 +121,12 @@ func TestProvider_SubKeys(t *testing.T) {
 			haveKey:  "workers",
 			want:     nil,
 		},
+		{
+			name:     "array indices",
+			haveJSON: `{"workers":[{"host":"h0"},{"host":"h1"}]}`,
+			haveKey:  "workers",
+			want:     []string{"0", "1"},
+		},
 		{
 			name:     "prefix not found",
 			haveJSON: `{"foo":{"bar":"baz"}}`,
@@ -132,3 +147,67 @@ func TestProvider_SubKeys(t *testing.T) {
 		})
 	}
 }
+
+func TestProvider_Keys(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		haveJSON string
+		want     []string
+	}{
+		{
+			name:     "empty store",
+			haveJSON: `{}`,
+			want:     nil,
+		},
+		{
+			name:     "nested values",
+			haveJSON: `{"timeout":"1s","db":{"host":"h","port":5432,"opts":{}}}`,
+			want:     []string{"db.host", "db.port", "timeout"},
+		},
+		{
+			name:     "array items",
+			haveJSON: `{"hosts":["h0","h1"],"workers":[{"host":"w0"}]}`,
+			want:     []string{"hosts.0", "hosts.1", "workers.0.host"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			p := NewProviderFromReader(strings.NewReader(tc.haveJSON))
+
+			got, err := p.(*Provider).Keys(context.Background())
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+type workerConfig struct {
+	Host string `json:"host"`
+}
+
+type populateConfig struct {
+	Workers   []workerConfig          `json:"workers"`
+	Databases map[string]workerConfig `json:"databases"`
+}
+
+func TestPopulate(t *testing.T) {
+	var c populateConfig
+
+	err := cfg.NewConfigurator(
+		NewProviderFromReader(
+			strings.NewReader(
+				`{"workers":[{"host":"w0"},{"host":"w1"}],"databases":{"primary":{"host":"h1"}}}`,
+			),
+		),
+	).Populate(context.Background(), &c)
+
+	require.NoError(t, err)
+	assert.Equal(
+		t,
+		populateConfig{
+			Workers:   []workerConfig{{Host: "w0"}, {Host: "w1"}},
+			Databases: map[string]workerConfig{"primary": {Host: "h1"}},
+		},
+		c,
+	)
+}
@@ -105,3 +105,29 @@ func (*defaultFQProvider) SubKeys(context.Context, string) ([]string, error) {
 type KeyFormatter interface {
 	FormatKey(string) string
 }
+
+// Watcher is an optional interface that providers can implement to
+// notify the configurator that the values they serve changed.  The
+// returned channel receives a value each time the underlying source
+// changed and is closed once the context is done.  Providers decorating
+// another one return a nil channel when the decorated provider can not be
+// watched.
+type Watcher interface {
+	Watch(context.Context) <-chan struct{}
+}
+
+// KeyLister is an optional interface that providers can implement to
+// enumerate every key they hold, e.g. to report the ones no struct field
+// reads.
+type KeyLister interface {
+	Keys(context.Context) ([]string, error)
+}
+
+// MultiValueProvider is an optional interface that providers can
+// implement when a key can be given several times, such as a repeated
+// flag.  The values of the slice and map fields are read through
+// ProvideValues and accumulated, in the order they were given, while the
+// other fields keep the last value returned by Provide.
+type MultiValueProvider interface {
+	ProvideValues(context.Context, string) ([]string, bool, error)
+}
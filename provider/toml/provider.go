@@ -0,0 +1,59 @@
+package toml
+
+import (
+	"context"
+	"io"
+
+	"github.com/pelletier/go-toml/v2"
+
+	"github.com/upfluence/cfg/internal/stringutil"
+	"github.com/upfluence/cfg/internal/tree"
+	"github.com/upfluence/cfg/provider"
+)
+
+const StructTag = "toml"
+
+var ErrTOMLMalformated = tree.ErrMalformated
+
+type Provider struct {
+	store map[string]interface{}
+}
+
+func NewProviderFromReader(r io.Reader) provider.Provider {
+	var v = make(map[string]interface{})
+
+	if err := toml.NewDecoder(r).Decode(&v); err != nil {
+		return provider.ProvideError(StructTag, err)
+	}
+
+	return &Provider{store: v}
+}
+
+func (*Provider) StructTag() string { return StructTag }
+
+func (*Provider) DefaultFieldValue(fieldName string) string {
+	return fieldName
+}
+
+func (*Provider) JoinFieldKeys(prefix, key string) string {
+	return prefix + "." + key
+}
+
+func (p *Provider) Provide(_ context.Context, k string) (string, bool, error) {
+	v, ok, err := tree.Lookup(p.store, k)
+
+	if err != nil || !ok {
+		return "", false, err
+	}
+
+	return stringutil.Stringify(v), true, nil
+}
+
+func (p *Provider) SubKeys(_ context.Context, prefix string) ([]string, error) {
+	return tree.SubKeys(p.store, prefix), nil
+}
+
+// Keys returns the keys of the scalar values of the document.
+func (p *Provider) Keys(context.Context) ([]string, error) {
+	return tree.Keys(p.store), nil
+}
@@ -0,0 +1,189 @@
+package toml
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/upfluence/cfg"
+)
+
+func TestProvider_Provide(t *testing.T) {
+	for _, tc := range []struct {
+		name      string
+		haveTOML  string
+		haveKey   string
+		wantValue string
+		wantExist bool
+		wantErr   error
+	}{
+		{
+			name:     "empty store",
+			haveTOML: ``,
+			haveKey:  "foo",
+		},
+		{
+			name:      "top level value",
+			haveTOML:  `foo = "bar"`,
+			haveKey:   "foo",
+			wantValue: "bar",
+			wantExist: true,
+		},
+		{
+			name:      "array value",
+			haveTOML:  `foo = [1, 2, 3]`,
+			haveKey:   "foo",
+			wantValue: "1,2,3",
+			wantExist: true,
+		},
+		{
+			name:      "nested table",
+			haveTOML:  "[foo.fiz]\nbuz = 42\n",
+			haveKey:   "foo.fiz.buz",
+			wantValue: "42",
+			wantExist: true,
+		},
+		{
+			name:      "array of tables",
+			haveTOML:  "[[foo]]\nfiz = \"bar\"\n[[foo]]\nfiz = \"buz\"\n",
+			haveKey:   "foo.1.fiz",
+			wantValue: "buz",
+			wantExist: true,
+		},
+		{
+			name:      "offset datetime",
+			haveTOML:  `foo = 1979-05-27T07:32:00.999999-07:00`,
+			haveKey:   "foo",
+			wantValue: "1979-05-27T07:32:00.999999-07:00",
+			wantExist: true,
+		},
+		{
+			name:      "local datetime",
+			haveTOML:  `foo = 1979-05-27T07:32:00.123`,
+			haveKey:   "foo",
+			wantValue: "1979-05-27T07:32:00.123",
+			wantExist: true,
+		},
+		{
+			name:      "local date",
+			haveTOML:  `foo = 1979-05-27`,
+			haveKey:   "foo",
+			wantValue: "1979-05-27",
+			wantExist: true,
+		},
+		{
+			name:     "wrong format",
+			haveTOML: "[foo]\nfiz = \"bar\"\n",
+			haveKey:  "foo.fiz.buz",
+			wantErr:  ErrTOMLMalformated,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			p := NewProviderFromReader(strings.NewReader(tc.haveTOML))
+
+			got, gotExist, err := p.Provide(context.Background(), tc.haveKey)
+
+			require.ErrorIs(t, err, tc.wantErr)
+			assert.Equal(t, tc.wantValue, got)
+			assert.Equal(t, tc.wantExist, gotExist)
+		})
+	}
+}
+
+func TestProvider_SubKeys(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		haveTOML string
+		haveKey  string
+		want     []string
+	}{
+		{
+			name:     "empty store",
+			haveTOML: ``,
+			haveKey:  "workers",
+			want:     nil,
+		},
+		{
+			name:     "table keys",
+			haveTOML: "[db.primary]\nhost = \"h1\"\n[db.replica]\nhost = \"h2\"\n",
+			haveKey:  "db",
+			want:     []string{"primary", "replica"},
+		},
+		{
+			name:     "array of tables indices",
+			haveTOML: "[[workers]]\nhost = \"h0\"\n[[workers]]\nhost = \"h1\"\n",
+			haveKey:  "workers",
+			want:     []string{"0", "1"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			p := NewProviderFromReader(strings.NewReader(tc.haveTOML))
+
+			got, err := p.(*Provider).SubKeys(context.Background(), tc.haveKey)
+
+			require.NoError(t, err)
+
+			sort.Strings(got)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestNewProviderFromReader(t *testing.T) {
+	p := NewProviderFromReader(strings.NewReader("foo = "))
+
+	_, _, err := p.Provide(context.Background(), "foo")
+
+	assert.Error(t, err)
+	assert.Equal(t, StructTag, p.StructTag())
+}
+
+type workerConfig struct {
+	Host string `toml:"host"`
+}
+
+type populateConfig struct {
+	Name      string         `toml:"name"`
+	StartedAt time.Time      `toml:"started_at"`
+	Day       time.Time      `toml:"day"`
+	Workers   []workerConfig `toml:"workers"`
+}
+
+func TestPopulate(t *testing.T) {
+	var (
+		c populateConfig
+
+		p = NewProviderFromReader(
+			strings.NewReader(`
+name = "app"
+started_at = 1979-05-27T07:32:00.999999999Z
+day = 1979-05-27
+
+[[workers]]
+host = "w0"
+
+[[workers]]
+host = "w1"
+`),
+		)
+	)
+
+	err := cfg.NewConfigurator(p).Populate(context.Background(), &c)
+
+	require.NoError(t, err)
+	assert.Equal(
+		t,
+		populateConfig{
+			Name:      "app",
+			StartedAt: time.Date(1979, 5, 27, 7, 32, 0, 999999999, time.UTC),
+			Day:       time.Date(1979, 5, 27, 0, 0, 0, 0, time.UTC),
+			Workers:   []workerConfig{{Host: "w0"}, {Host: "w1"}},
+		},
+		c,
+	)
+}
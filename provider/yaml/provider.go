@@ -0,0 +1,70 @@
+package yaml
+
+import (
+	"context"
+	"io"
+
+	"github.com/upfluence/errors"
+	"gopkg.in/yaml.v3"
+
+	"github.com/upfluence/cfg/internal/stringutil"
+	"github.com/upfluence/cfg/internal/tree"
+	"github.com/upfluence/cfg/provider"
+)
+
+const StructTag = "yaml"
+
+var ErrYAMLMalformated = tree.ErrMalformated
+
+type Provider struct {
+	store map[string]interface{}
+}
+
+func NewProviderFromReader(r io.Reader) provider.Provider {
+	var v interface{}
+
+	if err := yaml.NewDecoder(r).Decode(&v); err != nil && !errors.Is(err, io.EOF) {
+		return provider.ProvideError(StructTag, err)
+	}
+
+	if v == nil {
+		return &Provider{store: make(map[string]interface{})}
+	}
+
+	store, ok := tree.Normalize(v).(map[string]interface{})
+
+	if !ok {
+		return provider.ProvideError(StructTag, ErrYAMLMalformated)
+	}
+
+	return &Provider{store: store}
+}
+
+func (*Provider) StructTag() string { return StructTag }
+
+func (*Provider) DefaultFieldValue(fieldName string) string {
+	return fieldName
+}
+
+func (*Provider) JoinFieldKeys(prefix, key string) string {
+	return prefix + "." + key
+}
+
+func (p *Provider) Provide(_ context.Context, k string) (string, bool, error) {
+	v, ok, err := tree.Lookup(p.store, k)
+
+	if err != nil || !ok {
+		return "", false, err
+	}
+
+	return stringutil.Stringify(v), true, nil
+}
+
+func (p *Provider) SubKeys(_ context.Context, prefix string) ([]string, error) {
+	return tree.SubKeys(p.store, prefix), nil
+}
+
+// Keys returns the keys of the scalar values of the document.
+func (p *Provider) Keys(context.Context) ([]string, error) {
+	return tree.Keys(p.store), nil
+}
@@ -0,0 +1,205 @@
+package yaml
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/upfluence/cfg"
+)
+
+func TestProvider_Provide(t *testing.T) {
+	for _, tc := range []struct {
+		name      string
+		haveYAML  string
+		haveKey   string
+		wantValue string
+		assertVal func(*testing.T, string)
+		wantExist bool
+		wantErr   error
+	}{
+		{
+			name:     "empty store",
+			haveYAML: ``,
+			haveKey:  "foo",
+		},
+		{
+			name:      "top level value",
+			haveYAML:  "foo: bar",
+			haveKey:   "foo",
+			wantValue: "bar",
+			wantExist: true,
+		},
+		{
+			name:      "sequence value",
+			haveYAML:  "foo: [1, 2, 3]",
+			haveKey:   "foo",
+			wantValue: "1,2,3",
+			wantExist: true,
+		},
+		{
+			name:     "map value",
+			haveYAML: "foo: {foo: 1, bar: 2}",
+			haveKey:  "foo",
+			assertVal: func(t *testing.T, got string) {
+				t.Helper()
+				assert.Contains(t, []string{"foo=1,bar=2", "bar=2,foo=1"}, got)
+			},
+			wantExist: true,
+		},
+		{
+			name:      "second level value",
+			haveYAML:  "foo:\n  fiz: bar\n",
+			haveKey:   "foo.fiz",
+			wantValue: "bar",
+			wantExist: true,
+		},
+		{
+			name:      "non string keys",
+			haveYAML:  "foo:\n  1: bar\n",
+			haveKey:   "foo.1",
+			wantValue: "bar",
+			wantExist: true,
+		},
+		{
+			name:      "sequence item",
+			haveYAML:  "foo:\n  - fiz: bar\n  - fiz: buz\n",
+			haveKey:   "foo.1.fiz",
+			wantValue: "buz",
+			wantExist: true,
+		},
+		{
+			name:     "sequence item out of range",
+			haveYAML: "foo:\n  - fiz: bar\n",
+			haveKey:  "foo.1.fiz",
+		},
+		{
+			name:     "wrong format",
+			haveYAML: "foo:\n  fiz: bar\n",
+			haveKey:  "foo.fiz.buz",
+			wantErr:  ErrYAMLMalformated,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			p := NewProviderFromReader(strings.NewReader(tc.haveYAML))
+
+			got, gotExist, err := p.Provide(context.Background(), tc.haveKey)
+
+			require.ErrorIs(t, err, tc.wantErr)
+
+			if tc.assertVal != nil {
+				tc.assertVal(t, got)
+			} else {
+				assert.Equal(t, tc.wantValue, got)
+			}
+
+			assert.Equal(t, tc.wantExist, gotExist)
+		})
+	}
+}
+
+func TestProvider_SubKeys(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		haveYAML string
+		haveKey  string
+		want     []string
+	}{
+		{
+			name:     "empty store",
+			haveYAML: ``,
+			haveKey:  "workers",
+			want:     nil,
+		},
+		{
+			name:     "map keys",
+			haveYAML: "db:\n  primary:\n    host: h1\n  replica:\n    host: h2\n",
+			haveKey:  "db",
+			want:     []string{"primary", "replica"},
+		},
+		{
+			name:     "sequence indices",
+			haveYAML: "workers:\n  - host: h0\n  - host: h1\n",
+			haveKey:  "workers",
+			want:     []string{"0", "1"},
+		},
+		{
+			name:     "prefix points to scalar",
+			haveYAML: "workers: not-a-map",
+			haveKey:  "workers",
+			want:     nil,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			p := NewProviderFromReader(strings.NewReader(tc.haveYAML))
+
+			got, err := p.(*Provider).SubKeys(context.Background(), tc.haveKey)
+
+			require.NoError(t, err)
+
+			sort.Strings(got)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestNewProviderFromReader(t *testing.T) {
+	p := NewProviderFromReader(strings.NewReader("- foo\n- bar\n"))
+
+	_, _, err := p.Provide(context.Background(), "foo")
+
+	assert.ErrorIs(t, err, ErrYAMLMalformated)
+	assert.Equal(t, StructTag, p.StructTag())
+}
+
+type dbConfig struct {
+	Host string `yaml:"host"`
+	Port int    `yaml:"port"`
+}
+
+type populateConfig struct {
+	Name      string              `yaml:"name"`
+	Tags      []string            `yaml:"tags"`
+	Databases map[string]dbConfig `yaml:"databases"`
+	Workers   []*dbConfig         `yaml:"workers"`
+}
+
+func TestPopulate(t *testing.T) {
+	var (
+		c populateConfig
+
+		p = NewProviderFromReader(
+			strings.NewReader(`
+name: app
+tags: [a, b]
+databases:
+  primary:
+    host: h1
+    port: 5432
+workers:
+  - host: w0
+    port: 1
+  - host: w1
+    port: 2
+`),
+		)
+	)
+
+	err := cfg.NewConfigurator(p).Populate(context.Background(), &c)
+
+	require.NoError(t, err)
+	assert.Equal(
+		t,
+		populateConfig{
+			Name:      "app",
+			Tags:      []string{"a", "b"},
+			Databases: map[string]dbConfig{"primary": {Host: "h1", Port: 5432}},
+			Workers:   []*dbConfig{{Host: "w0", Port: 1}, {Host: "w1", Port: 2}},
+		},
+		c,
+	)
+}
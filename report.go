@@ -0,0 +1,178 @@
+package cfg
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/upfluence/errors"
+
+	"github.com/upfluence/cfg/internal/redact"
+	"github.com/upfluence/cfg/provider"
+	dflt "github.com/upfluence/cfg/provider/default"
+)
+
+var ErrReportNotSupported = errors.New("cfg: configurator does not support reports")
+
+// Source describes a value handed to a field by a provider.
+type Source struct {
+	Provider string
+	Key      string
+	Value    string
+}
+
+func (s Source) String() string {
+	if s.Key == s.Value {
+		return fmt.Sprintf("%s: %q", s.Provider, s.Value)
+	}
+
+	return fmt.Sprintf("%s: %s=%q", s.Provider, s.Key, s.Value)
+}
+
+func newSource(p provider.Provider, k, v string, secret bool) Source {
+	s := Source{Provider: p.StructTag(), Key: k, Value: v}
+
+	if secret {
+		s.Value = redact.String(v)
+
+		// The keys of the default provider are the values themselves.
+		if _, ok := p.(dflt.Provider); ok {
+			s.Key = redact.String(k)
+		}
+	}
+
+	return s
+}
+
+// FieldReport tells where the value of a field came from.  Source is the
+// zero value when no provider set the field, Overridden lists the values
+// set by the providers of lower precedence, in the order they were
+// applied.
+//
+// Merge is the merge tag of the slice and map fields combining the values
+// of successive providers, e.g. "append".  The values combined with the
+// one of Source are then listed by Merged instead of Overridden.
+type FieldReport struct {
+	Path string
+
+	Source     Source
+	Overridden []Source
+
+	Merge  string
+	Merged []Source
+}
+
+// IsSet reports whether a provider set the field.
+func (fr FieldReport) IsSet() bool { return fr.Source.Provider != "" }
+
+// Report lists, for each field of a populated struct, the provider that
+// set its value.
+type Report struct {
+	Fields []FieldReport
+}
+
+func (r *Report) add(path string, sources []Source, ms mergeStrategy) {
+	fr := FieldReport{Path: path}
+
+	if ms != mergeReplace {
+		fr.Merge = ms.String()
+	}
+
+	if n := len(sources); n > 0 {
+		fr.Source = sources[n-1]
+
+		switch {
+		case n == 1:
+		case ms == mergeReplace:
+			fr.Overridden = sources[:n-1]
+		default:
+			fr.Merged = sources[:n-1]
+		}
+	}
+
+	r.Fields = append(r.Fields, fr)
+}
+
+// Lookup returns the report of the field at the given path, e.g.
+// "Database.Host".
+func (r *Report) Lookup(path string) (FieldReport, bool) {
+	for _, fr := range r.Fields {
+		if fr.Path == path {
+			return fr, true
+		}
+	}
+
+	return FieldReport{}, false
+}
+
+func (r *Report) WriteTo(w io.Writer) (int64, error) {
+	var b bytes.Buffer
+
+	for _, fr := range r.Fields {
+		b.WriteString(fr.Path)
+
+		if !fr.IsSet() {
+			b.WriteString(": <unset>\n")
+			continue
+		}
+
+		b.WriteString(" = ")
+		b.WriteString(fr.Source.String())
+
+		writeSources(&b, "overrides", fr.Overridden)
+		writeSources(&b, "merged from", fr.Merged)
+
+		b.WriteRune('\n')
+	}
+
+	return b.WriteTo(w)
+}
+
+func writeSources(b *bytes.Buffer, verb string, sources []Source) {
+	if len(sources) == 0 {
+		return
+	}
+
+	ss := make([]string, len(sources))
+
+	for i, s := range sources {
+		ss[i] = s.String()
+	}
+
+	fmt.Fprintf(b, " (%s %s)", verb, strings.Join(ss, ", "))
+}
+
+func (r *Report) String() string {
+	var b strings.Builder
+
+	_, _ = r.WriteTo(&b)
+
+	return b.String()
+}
+
+type reportingConfigurator interface {
+	PopulateWithReport(context.Context, interface{}) (*Report, error)
+}
+
+// PopulateWithReport populates in like Configurator.Populate and returns
+// the provenance of every field value.  The report is returned even when
+// the population failed, describing the fields handled so far.
+func PopulateWithReport(ctx context.Context, c Configurator, in interface{}) (*Report, error) {
+	rc, ok := c.(reportingConfigurator)
+
+	if !ok {
+		return nil, ErrReportNotSupported
+	}
+
+	return rc.PopulateWithReport(ctx, in)
+}
+
+func (c *configurator) PopulateWithReport(ctx context.Context, in interface{}) (*Report, error) {
+	var r Report
+
+	err := c.populateRoot(ctx, in, &populateState{report: &r})
+
+	return &r, err
+}
@@ -0,0 +1,111 @@
+package cfg
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	dflt "github.com/upfluence/cfg/provider/default"
+	"github.com/upfluence/cfg/provider/static"
+)
+
+type reportInner struct {
+	Port int `mock:"port"`
+}
+
+type reportConfig struct {
+	Timeout string                 `mock:"timeout" default:"5s"`
+	Name    string                 `mock:"name"`
+	DB      reportInner            `mock:"db"`
+	Workers map[string]reportInner `mock:"workers"`
+}
+
+func TestPopulateWithReport(t *testing.T) {
+	var (
+		c reportConfig
+
+		p = &mockProvider{
+			st: map[string]string{
+				"timeout":        "10s",
+				"db.port":        "5432",
+				"workers.a.port": "1",
+			},
+		}
+	)
+
+	r, err := PopulateWithReport(
+		context.Background(),
+		NewConfigurator(dflt.Provider{}, p),
+		&c,
+	)
+
+	require.NoError(t, err)
+	assert.Equal(
+		t,
+		&Report{
+			Fields: []FieldReport{
+				{
+					Path:       "Timeout",
+					Source:     Source{Provider: "mock", Key: "timeout", Value: "10s"},
+					Overridden: []Source{{Provider: "default", Key: "5s", Value: "5s"}},
+				},
+				{Path: "Name"},
+				{
+					Path:   "DB.Port",
+					Source: Source{Provider: "mock", Key: "db.port", Value: "5432"},
+				},
+				{
+					Path:   "Workers.a.Port",
+					Source: Source{Provider: "mock", Key: "workers.a.port", Value: "1"},
+				},
+			},
+		},
+		r,
+	)
+
+	fr, ok := r.Lookup("Timeout")
+
+	assert.True(t, ok)
+	assert.True(t, fr.IsSet())
+
+	assert.Equal(
+		t,
+		"Timeout = mock: timeout=\"10s\" (overrides default: \"5s\")\n"+
+			"Name: <unset>\n"+
+			"DB.Port = mock: db.port=\"5432\"\n"+
+			"Workers.a.Port = mock: workers.a.port=\"1\"\n",
+		r.String(),
+	)
+}
+
+func TestPopulateWithReport_Secret(t *testing.T) {
+	r, err := PopulateWithReport(
+		context.Background(),
+		NewConfigurator(
+			dflt.Provider{},
+			&mockProvider{st: map[string]string{"token": "hunter2"}},
+		),
+		&struct {
+			Token string `mock:"token" default:"changeme" secret:"true"`
+		}{},
+	)
+
+	require.NoError(t, err)
+	assert.Equal(
+		t,
+		"Token = mock: token=\"<redacted>\" (overrides default: \"<redacted>\")\n",
+		r.String(),
+	)
+}
+
+func TestPopulateWithReport_NotSupported(t *testing.T) {
+	_, err := PopulateWithReport(
+		context.Background(),
+		struct{ Configurator }{NewConfigurator(static.NewProvider(nil))},
+		&reportConfig{},
+	)
+
+	assert.Equal(t, ErrReportNotSupported, err)
+}
@@ -0,0 +1,85 @@
+package cfg
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/upfluence/cfg/provider"
+	dflt "github.com/upfluence/cfg/provider/default"
+	"github.com/upfluence/cfg/provider/flags"
+)
+
+type sourcesConfig struct {
+	Token    string `env:"TOKEN" flag:"token" sources:"env"`
+	Level    string `env:"LEVEL" flag:"level" sources:"env,flag"`
+	Name     string `env:"NAME" flag:"name"`
+	Timeout  int    `env:"TIMEOUT" default:"10" sources:"flag"`
+	Database struct {
+		URL string `env:"URL" flag:"url"`
+	} `env:"DB" flag:"db" sources:"env"`
+}
+
+func TestSources(t *testing.T) {
+	var c sourcesConfig
+
+	err := NewConfigurator(
+		dflt.Provider{},
+		flags.NewProvider(
+			[]string{"--token", "flag", "--level", "flag", "--name", "flag", "--db.url", "flag"},
+		),
+		provider.NewStaticProvider(
+			"env",
+			map[string]string{
+				"TOKEN":   "env",
+				"LEVEL":   "env",
+				"NAME":    "env",
+				"TIMEOUT": "30",
+				"DB.URL":  "env",
+			},
+			nil,
+		),
+	).Populate(context.Background(), &c)
+
+	require.NoError(t, err)
+	assert.Equal(t, "env", c.Token)
+	assert.Equal(t, "flag", c.Level)
+	assert.Equal(t, "env", c.Name)
+	assert.Equal(t, 10, c.Timeout)
+	assert.Equal(t, "env", c.Database.URL)
+}
+
+func TestSourcesInterpolate(t *testing.T) {
+	var (
+		c struct {
+			Token string `env:"TOKEN" sources:"env"`
+			Name  string `env:"NAME"`
+		}
+
+		ps = []provider.Provider{
+			&mockProvider{st: map[string]string{"cfg_test_secret": "leaked"}},
+			provider.NewStaticProvider(
+				"env",
+				map[string]string{"NAME": "${cfg_test_secret}"},
+				nil,
+			),
+		}
+	)
+
+	err := NewConfigurator(ps...).WithOptions(Interpolate).Populate(context.Background(), &c)
+
+	require.NoError(t, err)
+	assert.Equal(t, "leaked", c.Name)
+
+	ps[1] = provider.NewStaticProvider(
+		"env",
+		map[string]string{"TOKEN": "${cfg_test_secret}"},
+		nil,
+	)
+
+	err = NewConfigurator(ps...).WithOptions(Interpolate).Populate(context.Background(), &c)
+
+	assert.ErrorIs(t, err, ErrUndefinedReference)
+}
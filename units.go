@@ -0,0 +1,37 @@
+package cfg
+
+import "github.com/upfluence/cfg/internal/setter"
+
+type (
+	// ByteSize is a number of bytes, set from a human size such as
+	// "512MiB" or "1.5GB".  The decimal units (KB, MB, ...) are powers of
+	// 1000 and the binary ones (KiB, MiB, ...) powers of 1024.
+	ByteSize = setter.ByteSize
+
+	// Quantity is a count set from a number with an optional SI suffix,
+	// e.g. "10k" or "1.5M".
+	Quantity = setter.Quantity
+
+	// Rate is a number of events per second, set from a quantity per
+	// period, e.g. "10k/s", "100/m" or "5/100ms".
+	Rate = setter.Rate
+
+	// Percent is a ratio set from a percentage, e.g. "50%" is 0.5.
+	Percent = setter.Percent
+)
+
+const (
+	B = setter.B
+
+	KB = setter.KB
+	MB = setter.MB
+	GB = setter.GB
+	TB = setter.TB
+	PB = setter.PB
+
+	KiB = setter.KiB
+	MiB = setter.MiB
+	GiB = setter.GiB
+	TiB = setter.TiB
+	PiB = setter.PiB
+)
@@ -0,0 +1,104 @@
+package cfg
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/upfluence/cfg/internal/setter"
+	"github.com/upfluence/cfg/provider"
+)
+
+func TestUnitsString(t *testing.T) {
+	for _, tt := range []struct {
+		in   fmt.Stringer
+		want string
+	}{
+		{in: ByteSize(0), want: "0B"},
+		{in: 512 * MiB, want: "512MiB"},
+		{in: 3 * GB, want: "3GB"},
+		{in: 1536 * KiB, want: "1536KiB"},
+		{in: ByteSize(1001), want: "1001B"},
+		{in: Quantity(1500), want: "1.5k"},
+		{in: Quantity(42), want: "42"},
+		{in: Rate(10000), want: "10k/s"},
+		{in: Rate(0.5), want: "30/m"},
+		{in: Rate(1.0 / 7200), want: "0.5/h"},
+		{in: Percent(0.125), want: "12.5%"},
+		{in: Percent(0.07), want: "7%"},
+	} {
+		assert.Equal(t, tt.want, tt.in.String())
+	}
+}
+
+type unitsConfig struct {
+	BufferSize ByteSize `env:"BUFFER_SIZE" default:"4MiB"`
+	MaxItems   Quantity `env:"MAX_ITEMS"`
+	Limit      Rate     `env:"LIMIT"`
+	Sampling   Percent  `env:"SAMPLING" max:"100%"`
+}
+
+func TestUnits(t *testing.T) {
+	var c unitsConfig
+
+	err := NewConfigurator(
+		provider.NewStaticProvider(
+			"env",
+			map[string]string{"MAX_ITEMS": "10k", "LIMIT": "100/m", "SAMPLING": "5%"},
+			nil,
+		),
+	).Populate(context.Background(), &c)
+
+	require.NoError(t, err)
+	assert.Equal(t, unitsConfig{MaxItems: 10000, Limit: Rate(100.0 / 60), Sampling: 0.05}, c)
+
+	err = NewConfigurator(
+		provider.NewStaticProvider("env", map[string]string{"SAMPLING": "150%"}, nil),
+	).WithOptions(CollectErrors).Populate(context.Background(), &c)
+
+	var ve *ValidationError
+
+	assert.ErrorAs(t, err, &ve)
+}
+
+func TestUnitsHelp(t *testing.T) {
+	var b bytes.Buffer
+
+	cfg := NewDefaultConfigurator().(*helpConfigurator)
+	cfg.stderr = &b
+
+	require.NoError(
+		t,
+		cfg.PrintDefaults(&unitsConfig{MaxItems: 2000, Limit: 10, Sampling: 0.25}),
+	)
+	assert.Equal(
+		t,
+		"Arguments:\n"+
+			"\t- BufferSize: bytesize (default: 4MiB) (env: BUFFER_SIZE, flag: --buffer-size)\n"+
+			"\t- MaxItems: quantity (default: 2k) (env: MAX_ITEMS, flag: --max-items)\n"+
+			"\t- Limit: rate (default: 10/s) (env: LIMIT, flag: --limit)\n"+
+			"\t- Sampling: percent (default: 25%) (max: 100%) (env: SAMPLING, flag: --sampling)\n",
+		b.String(),
+	)
+}
+
+func TestPercentRoundTrip(t *testing.T) {
+	for _, p := range []Percent{0.07, 0.125, 1.0 / 3, 0.1 + 0.2, 1e-9, 123.456, -0.005, 0} {
+		got, err := setter.ParsePercent(p.String())
+
+		require.NoError(t, err)
+		assert.Equal(t, p, got, p.String())
+	}
+}
+
+func TestByteSizeOverflow(t *testing.T) {
+	for _, in := range []string{"8192PiB", "9223372036854775808", "9.3e18"} {
+		_, err := setter.ParseByteSize(in)
+
+		assert.Error(t, err, in)
+	}
+}
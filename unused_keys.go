@@ -0,0 +1,121 @@
+package cfg
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/upfluence/errors"
+
+	"github.com/upfluence/cfg/provider"
+)
+
+// UnusedKey is a key held by a provider that no field reads, e.g. a
+// misspelled key of a configuration file.
+type UnusedKey struct {
+	Key      string
+	Provider provider.Provider
+}
+
+func (uk UnusedKey) String() string {
+	k := uk.Key
+
+	if kf, ok := uk.Provider.(provider.KeyFormatter); ok {
+		k = kf.FormatKey(k)
+	}
+
+	return fmt.Sprintf("%s: %s", uk.Provider.StructTag(), k)
+}
+
+// UnusedKeysError is returned by Populate with the DisallowUnusedKeys
+// option when the providers hold keys no field reads.
+type UnusedKeysError struct {
+	Keys []UnusedKey
+}
+
+func (uke *UnusedKeysError) Error() string {
+	if len(uke.Keys) == 1 {
+		return fmt.Sprintf("unused key %s", uke.Keys[0])
+	}
+
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "%d unused keys:", len(uke.Keys))
+
+	for _, uk := range uke.Keys {
+		b.WriteString("\n\t- ")
+		b.WriteString(uk.String())
+	}
+
+	return b.String()
+}
+
+// UnusedKeysFunc is called by Populate with the keys of the providers no
+// field read, the error it returns is returned by Populate.
+type UnusedKeysFunc func(context.Context, []UnusedKey) error
+
+// ReportUnusedKeys makes Populate compare the keys held by the providers
+// implementing provider.KeyLister with the keys looked up while walking
+// the struct, and call fn with the ones left unread.  It can log them as
+// a warning or turn them into an error.
+//
+// The check is made for each Populate call, structs populated separately
+// from the same providers should share a KeyTracker and call UnusedKeys
+// instead.
+func ReportUnusedKeys(fn UnusedKeysFunc) Option {
+	return func(c *configurator) { c.unusedKeysFn = fn }
+}
+
+// DisallowUnusedKeys makes Populate return an *UnusedKeysError when the
+// providers hold keys no field reads.
+func DisallowUnusedKeys(c *configurator) {
+	c.unusedKeysFn = func(_ context.Context, uks []UnusedKey) error {
+		return &UnusedKeysError{Keys: uks}
+	}
+}
+
+// UnusedKeys returns the keys held by the providers implementing
+// provider.KeyLister that were not looked up by the configurators
+// tracking their keys in t.  A key is used when it, or one of its
+// parents, was looked up, the items of a slice or a map field being read
+// at once.
+func UnusedKeys(ctx context.Context, t *KeyTracker, ps ...provider.Provider) ([]UnusedKey, error) {
+	var uks []UnusedKey
+
+	for _, p := range ps {
+		kl, ok := p.(provider.KeyLister)
+
+		if !ok {
+			continue
+		}
+
+		ks, err := kl.Keys(ctx)
+
+		if err != nil {
+			return nil, errors.Wrapf(err, "list %q keys", p.StructTag())
+		}
+
+		var (
+			fqp     = provider.WrapFullyQualifiedProvider(p)
+			tracked = t.Keys(p.StructTag())
+		)
+
+		for _, k := range ks {
+			if !isKeyUsed(fqp, tracked, k) {
+				uks = append(uks, UnusedKey{Key: k, Provider: p})
+			}
+		}
+	}
+
+	return uks, nil
+}
+
+func isKeyUsed(fqp provider.FullyQualifiedProvider, tracked []string, k string) bool {
+	for _, tk := range tracked {
+		if k == tk || strings.HasPrefix(k, fqp.JoinFieldKeys(tk, "")) {
+			return true
+		}
+	}
+
+	return false
+}
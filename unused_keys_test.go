@@ -0,0 +1,153 @@
+package cfg
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/upfluence/cfg/provider"
+	"github.com/upfluence/cfg/provider/flags"
+	"github.com/upfluence/cfg/provider/json"
+)
+
+type unusedDBConfig struct {
+	Host string `json:"host"`
+}
+
+type unusedConfig struct {
+	Timeout   string                    `json:"timeout" flag:"timeout"`
+	Hosts     []string                  `json:"hosts"`
+	Databases map[string]unusedDBConfig `json:"databases"`
+}
+
+func jsonProvider(s string) provider.Provider {
+	return json.NewProviderFromReader(strings.NewReader(s))
+}
+
+func TestDisallowUnusedKeys(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		ps   []provider.Provider
+
+		wantKeys []string
+		errMsg   string
+	}{
+		{
+			name: "every key read",
+			ps: []provider.Provider{
+				jsonProvider(
+					`{"timeout":"1s","hosts":["h0","h1"],"databases":{"main":{"host":"h"}}}`,
+				),
+			},
+		},
+		{
+			name: "misspelled key",
+			ps: []provider.Provider{
+				jsonProvider(`{"time_out":"1s","hosts":["h0"]}`),
+			},
+			wantKeys: []string{"time_out"},
+			errMsg:   "unused key json: time_out",
+		},
+		{
+			name: "nested keys, flags left out",
+			ps: []provider.Provider{
+				jsonProvider(`{"databases":{"main":{"host":"h","prot":1}},"verbose":true}`),
+				flags.NewProvider([]string{"--timeout", "1s", "--verbose"}),
+			},
+			wantKeys: []string{"databases.main.prot", "verbose"},
+			errMsg:   "2 unused keys:\n\t- json: databases.main.prot\n\t- json: verbose",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var c unusedConfig
+
+			err := NewConfigurator(tc.ps...).WithOptions(DisallowUnusedKeys).Populate(
+				context.Background(),
+				&c,
+			)
+
+			if tc.errMsg == "" {
+				require.NoError(t, err)
+				return
+			}
+
+			var uke *UnusedKeysError
+
+			require.True(t, errors.As(err, &uke))
+			assert.Equal(t, tc.errMsg, err.Error())
+
+			var ks []string
+
+			for _, uk := range uke.Keys {
+				ks = append(ks, uk.Key)
+			}
+
+			assert.Equal(t, tc.wantKeys, ks)
+		})
+	}
+}
+
+func TestReportUnusedKeys(t *testing.T) {
+	var (
+		c   unusedConfig
+		got []UnusedKey
+	)
+
+	err := NewConfigurator(jsonProvider(`{"timeout":"1s","retries":3}`)).WithOptions(
+		ReportUnusedKeys(func(_ context.Context, uks []UnusedKey) error {
+			got = uks
+			return nil
+		}),
+	).Populate(context.Background(), &c)
+
+	require.NoError(t, err)
+	assert.Equal(t, "1s", c.Timeout)
+	require.Len(t, got, 1)
+	assert.Equal(t, "retries", got[0].Key)
+}
+
+func TestUnusedKeys(t *testing.T) {
+	type serverConfig struct {
+		Timeout string `json:"timeout"`
+	}
+
+	type dbConfig struct {
+		Databases map[string]unusedDBConfig `json:"databases"`
+	}
+
+	var (
+		ctx = context.Background()
+		kt  = NewKeyTracker()
+		p   = jsonProvider(`{"timeout":"1s","databases":{"main":{"host":"h"}},"debug":true}`)
+		c   = NewConfigurator(p).WithOptions(TrackKeys(kt))
+	)
+
+	require.NoError(t, c.Populate(ctx, &serverConfig{}))
+	require.NoError(t, c.Populate(ctx, &dbConfig{}))
+
+	uks, err := UnusedKeys(ctx, kt, p)
+
+	require.NoError(t, err)
+	assert.Equal(t, []UnusedKey{{Key: "debug", Provider: p}}, uks)
+}
+
+func TestDisallowUnusedKeysDefaultConfigurator(t *testing.T) {
+	var c unusedConfig
+
+	err := NewDefaultConfigurator(jsonProvider(`{"timeout":"1s"}`)).
+		WithOptions(DisallowUnusedKeys).
+		Populate(context.Background(), &c)
+
+	require.NoError(t, err)
+	assert.Equal(t, "1s", c.Timeout)
+
+	err = NewDefaultConfigurator(jsonProvider(`{"timeout":"1s","foo":1}`)).
+		WithOptions(DisallowUnusedKeys).
+		Populate(context.Background(), &c)
+
+	assert.EqualError(t, err, "unused key json: foo")
+}
@@ -0,0 +1,184 @@
+package cfg
+
+import (
+	"context"
+	"reflect"
+	"sort"
+
+	"github.com/upfluence/errors"
+
+	"github.com/upfluence/cfg/internal/reflectutil"
+	"github.com/upfluence/cfg/internal/walker"
+	"github.com/upfluence/cfg/provider"
+)
+
+var ErrWatchNotSupported = errors.New("cfg: configurator does not support watching")
+
+// Update is delivered by Watch each time a provider notified a change.
+// Value holds a freshly populated copy of the watched struct and Changed
+// the paths of the fields whose value differs from the previous update.
+// When the re-population failed, only Err is set.
+type Update struct {
+	Value   interface{}
+	Changed []string
+	Err     error
+}
+
+type watchingConfigurator interface {
+	Watch(context.Context, interface{}) (<-chan Update, error)
+}
+
+// Watch populates a fresh copy of the struct pointed by in each time one
+// of the providers of the configurator implementing provider.Watcher
+// notifies a change, and delivers it on the returned channel.  Updates
+// changing no field are not delivered.  The channel is closed once the
+// context is done.
+func Watch(ctx context.Context, c Configurator, in interface{}) (<-chan Update, error) {
+	wc, ok := c.(watchingConfigurator)
+
+	if !ok {
+		return nil, ErrWatchNotSupported
+	}
+
+	return wc.Watch(ctx, in)
+}
+
+func (c *configurator) Watch(ctx context.Context, in interface{}) (<-chan Update, error) {
+	t := reflect.TypeOf(in)
+
+	if t == nil || t.Kind() != reflect.Ptr || t.Elem().Kind() != reflect.Struct {
+		return nil, walker.ErrShouldBeAStructPtr
+	}
+
+	prev, err := c.snapshot(ctx, t.Elem())
+
+	if err != nil {
+		return nil, err
+	}
+
+	var chs []<-chan struct{}
+
+	for _, p := range c.providers {
+		if w, ok := p.(provider.Watcher); ok {
+			if ch := w.Watch(ctx); ch != nil {
+				chs = append(chs, ch)
+			}
+		}
+	}
+
+	if len(chs) == 0 {
+		return nil, ErrWatchNotSupported
+	}
+
+	var (
+		notify  = make(chan struct{}, 1)
+		updates = make(chan Update)
+	)
+
+	for _, ch := range chs {
+		go forwardNotifications(ch, notify)
+	}
+
+	go func() {
+		defer close(updates)
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-notify:
+			}
+
+			next, err := c.snapshot(ctx, t.Elem())
+
+			var u = Update{Err: err}
+
+			if err == nil {
+				if u.Changed = prev.diff(next); len(u.Changed) == 0 {
+					continue
+				}
+
+				u.Value = next.value
+				prev = next
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case updates <- u:
+			}
+		}
+	}()
+
+	return updates, nil
+}
+
+func forwardNotifications(in <-chan struct{}, out chan<- struct{}) {
+	for range in {
+		select {
+		case out <- struct{}{}:
+		default:
+		}
+	}
+}
+
+type snapshot struct {
+	value  interface{}
+	fields map[string]interface{}
+}
+
+func (c *configurator) snapshot(ctx context.Context, t reflect.Type) (*snapshot, error) {
+	v := reflect.New(t).Interface()
+
+	if err := c.Populate(ctx, v); err != nil {
+		return nil, err
+	}
+
+	s := snapshot{value: v, fields: make(map[string]interface{})}
+
+	if err := walker.Walk(
+		v,
+		func(f *walker.Field) error {
+			fv := reflectutil.IndirectedValue(f.Value).FieldByName(f.Field.Name)
+
+			if c.factory.Build(f.Field.Type) != nil {
+				s.fields[f.Path()] = fv.Interface()
+
+				return walker.SkipStruct
+			}
+
+			if reflectutil.SubKeyMapElem(f.Field.Type) != nil ||
+				reflectutil.SubKeySliceElem(f.Field.Type) != nil {
+				s.fields[f.Path()] = fv.Interface()
+
+				return walker.SkipStruct
+			}
+
+			return nil
+		},
+	); err != nil {
+		return nil, err
+	}
+
+	return &s, nil
+}
+
+func (s *snapshot) diff(next *snapshot) []string {
+	var changed []string
+
+	for k, v := range next.fields {
+		if pv, ok := s.fields[k]; !ok || !reflect.DeepEqual(pv, v) {
+			changed = append(changed, k)
+		}
+	}
+
+	for k := range s.fields {
+		if _, ok := next.fields[k]; !ok {
+			changed = append(changed, k)
+		}
+	}
+
+	sort.Strings(changed)
+
+	return changed
+}
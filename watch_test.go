@@ -0,0 +1,78 @@
+package cfg
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/upfluence/cfg/provider/json"
+	"github.com/upfluence/cfg/provider/static"
+)
+
+type watchConfig struct {
+	Name  string `json:"name"`
+	Port  int    `json:"port"`
+	Inner struct {
+		Debug bool `json:"debug"`
+	} `json:"inner"`
+}
+
+func TestWatch(t *testing.T) {
+	var (
+		path = filepath.Join(t.TempDir(), "config.json")
+		now  = time.Now()
+
+		write = func(content string, mtime time.Time) {
+			require.NoError(t, os.WriteFile(path, []byte(content), 0600))
+			require.NoError(t, os.Chtimes(path, mtime, mtime))
+		}
+	)
+
+	write(`{"name":"foo","port":1}`, now.Add(-time.Hour))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	c := NewConfigurator(json.NewProviderFromFile(path, json.WithPollInterval(5*time.Millisecond)))
+
+	ch, err := Watch(ctx, c, &watchConfig{})
+	require.NoError(t, err)
+
+	write(`{"name":"foo","port":2,"inner":{"debug":true}}`, now)
+
+	select {
+	case u := <-ch:
+		require.NoError(t, u.Err)
+		assert.Equal(t, []string{"Inner.Debug", "Port"}, u.Changed)
+
+		wc := u.Value.(*watchConfig)
+
+		assert.Equal(t, "foo", wc.Name)
+		assert.Equal(t, 2, wc.Port)
+		assert.True(t, wc.Inner.Debug)
+	case <-time.After(time.Second):
+		t.Fatal("no update delivered")
+	}
+
+	cancel()
+
+	for range ch {
+	}
+}
+
+func TestWatch_NotSupported(t *testing.T) {
+	c := NewConfigurator(static.NewProvider(map[string]string{}))
+
+	_, err := Watch(context.Background(), c, &watchConfig{})
+	assert.Equal(t, ErrWatchNotSupported, err)
+
+	c = NewConfigurator(json.NewProviderFromFile("/nonexistent.json"))
+
+	_, err = Watch(context.Background(), c, watchConfig{})
+	assert.Error(t, err)
+}
@@ -8,6 +8,7 @@ import (
 	"strings"
 
 	"github.com/upfluence/cfg"
+	"github.com/upfluence/cfg/internal/setter"
 	"github.com/upfluence/cfg/provider"
 	pflags "github.com/upfluence/cfg/provider/flags"
 )
@@ -16,6 +17,7 @@ type App struct {
 	ps      []provider.Provider
 	opts    []cfg.Option
 	newFunc NewConfiguratorFunc
+	factory setter.Factory
 
 	name string
 	args []string
@@ -24,6 +26,8 @@ type App struct {
 	stdout io.Writer
 	stderr io.Writer
 
+	strictFlags bool
+
 	cmd Command
 }
 
@@ -43,11 +47,18 @@ func NewApp(opts ...Option) *App {
 		stderr:  o.stderr,
 		opts:    o.opts,
 		newFunc: o.newFunc,
+		factory: cfg.ParserFactory(o.opts...),
 		cmd:     o.command(),
+
+		strictFlags: o.strictFlags,
 	}
 }
 
 func (a *App) parseArgs() ([]string, []string) {
+	return splitArgs(a.args)
+}
+
+func splitArgs(args []string) ([]string, []string) {
 	var (
 		cmds  []string
 		flags []string
@@ -56,7 +67,7 @@ func (a *App) parseArgs() ([]string, []string) {
 		nested bool
 	)
 
-	for _, arg := range a.args {
+	for _, arg := range args {
 		if len(arg) == 0 {
 			continue
 		}
@@ -106,19 +117,39 @@ func (a *App) commandContext() CommandContext {
 	var (
 		cmds, flags = a.parseArgs()
 		args        = make(map[string]string)
-		ps          = append(
-			a.ps,
-			pflags.NewProvider(flags),
-			argProvider(args),
-		)
+		fp          = pflags.NewProvider(flags)
 	)
 
-	return newCommandContext(
+	cctx := newCommandContext(
 		a,
 		cmds,
 		args,
-		a.newFunc(append(a.opts, cfg.WithProviders(ps...))...),
+		newConfigurator(
+			a.newFunc,
+			append(
+				[]cfg.Option{cfg.OnDeprecatedKey(cfg.WriteDeprecatedKey(a.stderr))},
+				a.opts...,
+			),
+			a.ps,
+			fp,
+			args,
+		),
 	)
+
+	cctx.flags = fp
+	cctx.strictFlags = a.strictFlags
+
+	return cctx
+}
+
+func newConfigurator(fn NewConfiguratorFunc, opts []cfg.Option, ps []provider.Provider, fp *pflags.Provider, args map[string]string) cfg.Configurator {
+	ps = append(
+		ps[:len(ps):len(ps)],
+		fp,
+		argProvider(args),
+	)
+
+	return fn(append(opts[:len(opts):len(opts)], cfg.WithProviders(ps...))...)
 }
 
 func (a *App) Run(ctx context.Context) {
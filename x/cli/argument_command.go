@@ -2,17 +2,107 @@ package cli
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"io"
+	"strings"
 )
 
+// CompleteFunc returns the values suggested by the shell completion for an
+// argument starting with the given prefix.
+type CompleteFunc func(ctx context.Context, cctx CommandContext, prefix string) ([]string, error)
+
+// ArgumentCommand reads the positional argument Variable, exposed by the
+// arg tag provider, before running Command.
 type ArgumentCommand struct {
 	Variable string
 	Command  Command
+
+	// Optional lets the argument be omitted, in which case Default is
+	// exposed, if any.
+	Optional bool
+	Default  string
+
+	// Variadic makes the argument take every remaining argument but the
+	// ones required by the nested argument commands. The values are
+	// exposed as a comma separated list, read by the slice fields.
+	Variadic bool
+
+	Complete CompleteFunc
 }
 
 func (sc ArgumentCommand) definition() CommandDefinition {
-	return CommandDefinition{Args: []string{sc.Variable}}
+	def := CommandDefinition{Args: []string{sc.Variable}}
+
+	if sc.Optional || sc.Variadic {
+		def.arities = map[string]arity{
+			sc.Variable: {optional: sc.Optional, variadic: sc.Variadic},
+		}
+	}
+
+	return def
+}
+
+// requiredArgs returns the number of positional arguments the command
+// requires.
+func requiredArgs(cmd Command) int {
+	switch tcmd := cmd.(type) {
+	case *baseCommand:
+		return requiredArgs(tcmd.Command)
+	case ArgumentCommand:
+		n := requiredArgs(tcmd.Command)
+
+		if !tcmd.Optional {
+			n++
+		}
+
+		return n
+	case SubCommand:
+		return 1
+	}
+
+	return 0
+}
+
+// take returns the number of arguments consumed out of the n available.
+func (sc ArgumentCommand) take(n int) int {
+	avail := n - requiredArgs(sc.Command)
+
+	switch {
+	case avail <= 0 && sc.Optional:
+		return 0
+	case avail <= 0:
+		// Let the argument be consumed, the nested commands will report
+		// the missing ones.
+		return min(n, 1)
+	case sc.Variadic:
+		return avail
+	}
+
+	return 1
+}
+
+// joinValues renders the values as a list read by the slice setters.
+func joinValues(vs []string) string {
+	qvs := make([]string, len(vs))
+
+	for i, v := range vs {
+		switch {
+		case !strings.ContainsAny(v, ",\"'"):
+			qvs[i] = v
+		case !strings.Contains(v, "\""):
+			qvs[i] = "\"" + v + "\""
+		case !strings.Contains(v, "'"):
+			qvs[i] = "'" + v + "'"
+		default:
+			// No quote protects v, the JSON array literal does.
+			buf, _ := json.Marshal(vs)
+
+			return string(buf)
+		}
+	}
+
+	return strings.Join(qvs, ",")
 }
 
 func (sc ArgumentCommand) WriteSynopsis(w io.Writer, opts IntrospectionOptions) (int, error) {
@@ -24,7 +114,9 @@ func (sc ArgumentCommand) WriteHelp(w io.Writer, opts IntrospectionOptions) (int
 }
 
 func (sc ArgumentCommand) Run(ctx context.Context, cctx CommandContext) error {
-	if len(cctx.Args) == 0 {
+	n := sc.take(len(cctx.Args))
+
+	if n == 0 && !sc.Optional {
 		ok, err := isHelpRequested(ctx, cctx)
 
 		if err != nil {
@@ -49,10 +141,21 @@ func (sc ArgumentCommand) Run(ctx context.Context, cctx CommandContext) error {
 		return err
 	}
 
-	subject := cctx.Args[0]
-	cctx.Args = cctx.Args[1:]
+	var vs = cctx.Args[:n]
+
+	cctx.Args = cctx.Args[n:]
 	cctx.Definitions = append(cctx.Definitions, sc.definition())
-	cctx.args[sc.Variable] = subject
+
+	switch {
+	case n == 0:
+		if sc.Default != "" {
+			cctx.args[sc.Variable] = sc.Default
+		}
+	case sc.Variadic:
+		cctx.args[sc.Variable] = joinValues(vs)
+	default:
+		cctx.args[sc.Variable] = vs[0]
+	}
 
 	return sc.Command.Run(ctx, cctx)
 }
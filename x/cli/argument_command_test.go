@@ -0,0 +1,165 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type cpArgs struct {
+	Sources     []string `arg:"src"`
+	Destination string   `arg:"dst"`
+}
+
+type logsArgs struct {
+	Pod       string `arg:"pod"`
+	Container string `arg:"container"`
+}
+
+func printArgs(v interface{}) StaticCommand {
+	return StaticCommand{
+		Execute: func(ctx context.Context, cctx CommandContext) error {
+			if err := cctx.Configurator.Populate(ctx, v); err != nil {
+				return err
+			}
+
+			_, err := fmt.Fprintf(cctx.Stdout, "%+v", v)
+
+			return err
+		},
+	}
+}
+
+func TestArgumentCommandArity(t *testing.T) {
+	cp := func() Command {
+		return ArgumentCommand{
+			Variable: "src",
+			Variadic: true,
+			Command: ArgumentCommand{
+				Variable: "dst",
+				Command:  printArgs(&cpArgs{}),
+			},
+		}
+	}
+
+	logs := func(dflt string) Command {
+		return ArgumentCommand{
+			Variable: "pod",
+			Command: ArgumentCommand{
+				Variable: "container",
+				Optional: true,
+				Default:  dflt,
+				Command:  printArgs(&logsArgs{}),
+			},
+		}
+	}
+
+	for _, tt := range []struct {
+		name string
+		cmd  Command
+		args []string
+
+		wantOut string
+		wantErr string
+	}{
+		{
+			name:    "variadic",
+			cmd:     cp(),
+			args:    []string{"a", "b,c", "d"},
+			wantOut: "&{Sources:[a b,c] Destination:d}",
+		},
+		{
+			name:    "variadic with quotes",
+			cmd:     cp(),
+			args:    []string{`it's "a"`, "b'c", `"d"`, "e"},
+			wantOut: `&{Sources:[it's "a" b'c "d"] Destination:e}`,
+		},
+		{
+			name:    "variadic with a single value",
+			cmd:     cp(),
+			args:    []string{"a", "d"},
+			wantOut: "&{Sources:[a] Destination:d}",
+		},
+		{
+			name: "variadic missing nested argument",
+			cmd:  cp(),
+			args: []string{"a"},
+			wantErr: `no argument found for variable "dst", follow the synopsis:
+cli-test a <dst> `,
+		},
+		{
+			name: "variadic missing",
+			cmd:  cp(),
+			wantErr: `no argument found for variable "src", follow the synopsis:
+cli-test <src>... <dst> `,
+		},
+		{
+			name:    "optional set",
+			cmd:     logs(""),
+			args:    []string{"api", "sidecar"},
+			wantOut: "&{Pod:api Container:sidecar}",
+		},
+		{
+			name:    "optional omitted",
+			cmd:     logs(""),
+			args:    []string{"api"},
+			wantOut: "&{Pod:api Container:}",
+		},
+		{
+			name:    "optional default",
+			cmd:     logs("main"),
+			args:    []string{"api"},
+			wantOut: "&{Pod:api Container:main}",
+		},
+		{
+			name:    "optional synopsis",
+			cmd:     logs(""),
+			args:    []string{"-h"},
+			wantErr: "usage: cli-test <pod> [container] ",
+		},
+		{
+			name:    "optional synopsis nested",
+			cmd:     logs(""),
+			args:    []string{"api", "-h"},
+			wantErr: "usage: cli-test api [container] ",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				outBuf bytes.Buffer
+				errBuf bytes.Buffer
+
+				a = NewApp(WithName("cli-test"), WithCommand(tt.cmd))
+			)
+
+			a.args = tt.args
+
+			cctx := a.commandContext()
+			cctx.Stdout = &outBuf
+			cctx.Stderr = &errBuf
+
+			err := a.cmd.Run(context.Background(), cctx)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantOut, outBuf.String())
+			assert.Equal(t, canonicalString(tt.wantErr), canonicalString(errBuf.String()))
+		})
+	}
+}
+
+func TestArityFormat(t *testing.T) {
+	for _, tt := range []struct {
+		arity arity
+		out   string
+	}{
+		{out: "<x>"},
+		{arity: arity{optional: true}, out: "[x]"},
+		{arity: arity{variadic: true}, out: "<x>..."},
+		{arity: arity{optional: true, variadic: true}, out: "[x...]"},
+	} {
+		assert.Equal(t, tt.out, tt.arity.format("x"))
+	}
+}
@@ -65,6 +65,12 @@ func (bc *baseCommand) Run(ctx context.Context, cctx CommandContext) error {
 		return bc.helpCmd.Run(ctx, cctx)
 	}
 
+	if cctx.strictFlags {
+		if err := checkFlags(ctx, cctx, bc.Command); err != nil {
+			return err
+		}
+	}
+
 	if bc.Command == nil {
 		cctx.Logger.Error("command not implemented")
 
@@ -10,6 +10,8 @@ import (
 	"github.com/upfluence/log/record"
 
 	"github.com/upfluence/cfg"
+	"github.com/upfluence/cfg/internal/setter"
+	pflags "github.com/upfluence/cfg/provider/flags"
 )
 
 type CommandContext struct {
@@ -27,6 +29,10 @@ type CommandContext struct {
 	args    map[string]string
 	appName string
 
+	flags       *pflags.Provider
+	strictFlags bool
+	factory     setter.Factory
+
 	env []string
 	wd  string
 }
@@ -43,6 +49,7 @@ func newCommandContext(a *App, cmds []string, args map[string]string, c cfg.Conf
 		Logger:       newLogger(a.stdout, a.stderr, record.Notice),
 		args:         args,
 		appName:      a.name,
+		factory:      a.factory,
 		wd:           wd,
 		env:          os.Environ(),
 	}
@@ -65,5 +72,6 @@ func (cctx CommandContext) introspectionOptions() IntrospectionOptions {
 		AppName:     cctx.appName,
 		Definitions: cctx.Definitions,
 		args:        cctx.args,
+		factory:     cctx.factory,
 	}
 }
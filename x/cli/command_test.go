@@ -93,6 +93,21 @@ func TestRun(t *testing.T) {
 		},
 	}
 
+	metaCmd := SubCommand{
+		Variable: "verb",
+		Commands: map[string]Command{
+			"delete": staticCmd,
+			"remove": staticCmd,
+			"debug":  staticCmd,
+			"start":  staticCmd,
+		},
+		Metadata: map[string]CommandMetadata{
+			"delete": {Aliases: []string{"rm"}, Group: "Storage"},
+			"remove": {Deprecated: `use "delete" instead`, Group: "Storage"},
+			"debug":  {Hidden: true},
+		},
+	}
+
 	for _, tt := range []struct {
 		opts []Option
 		args []string
@@ -155,6 +170,14 @@ cli-test <buz> `,
 			wantErr: `unknown command "buz", available commands:
 foo help foo
 help Print this message
+version Print the app version `,
+		},
+		{
+			args: []string{"fo"},
+			opts: []Option{WithCommand(subCmd)},
+			wantErr: `unknown command "fo", did you mean "foo"? available commands:
+foo help foo
+help Print this message
 version Print the app version `,
 		},
 		{
@@ -204,6 +227,45 @@ cli-test <arg_1> [-e, --example] [-o, --other]
 Arguments:
 - Example: string (env: EXAMPLE, flag: -e, --example)
 - Other: string (env: OTHER, flag: -o, --other) `,
+		},
+		{
+			args:    []string{"rm"},
+			opts:    []Option{WithCommand(metaCmd)},
+			wantOut: "success",
+		},
+		{
+			args:    []string{"debug"},
+			opts:    []Option{WithCommand(metaCmd)},
+			wantOut: "success",
+		},
+		{
+			args:    []string{"remove"},
+			opts:    []Option{WithCommand(metaCmd)},
+			wantOut: "success",
+			wantErr: "command \"remove\" is deprecated: use \"delete\" instead\n",
+		},
+		{
+			args: []string{"-h"},
+			opts: []Option{WithCommand(metaCmd)},
+			wantErr: `usage: cli-test <verb>
+Available sub commands:
+help Print this message
+start help foo
+version Print the app version
+Storage:
+delete, rm help foo
+remove help foo (deprecated) `,
+		},
+		{
+			args: []string{"r"},
+			opts: []Option{WithCommand(metaCmd)},
+			wantErr: `unknown command "r", did you mean "rm"? available commands:
+help Print this message
+start help foo
+version Print the app version
+Storage:
+delete, rm help foo
+remove help foo (deprecated) `,
 		},
 		{
 			args:    []string{"foo", "foo", "-h"},
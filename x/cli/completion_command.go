@@ -0,0 +1,286 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/upfluence/cfg"
+	"github.com/upfluence/cfg/internal/completion"
+	"github.com/upfluence/cfg/internal/setter"
+	"github.com/upfluence/cfg/provider"
+	pflags "github.com/upfluence/cfg/provider/flags"
+)
+
+const completeCommandName = "__complete"
+
+type completionCommand struct {
+	name string
+}
+
+func (cc *completionCommand) WriteHelp(w io.Writer, opts IntrospectionOptions) (int, error) {
+	if opts.Short {
+		return io.WriteString(w, "Print the shell completion script")
+	}
+
+	return writeHelp(
+		w,
+		opts.withDefinition(CommandDefinition{Args: []string{"bash|zsh|fish"}}),
+	)
+}
+
+func (cc *completionCommand) WriteSynopsis(io.Writer, IntrospectionOptions) (int, error) {
+	return 0, nil
+}
+
+func (cc *completionCommand) Run(ctx context.Context, cctx CommandContext) error {
+	if ok, err := isHelpRequested(ctx, cctx); err != nil || ok || len(cctx.Args) != 1 {
+		if err == nil {
+			_, err = cc.WriteHelp(cctx.Stderr, cctx.introspectionOptions())
+		}
+
+		return err
+	}
+
+	s := completion.Script{Name: cc.name, Command: completeCommandName}
+
+	return s.Write(cctx.Stdout, cctx.Args[0])
+}
+
+type completeConfig struct {
+	Current string `flag:"current"`
+}
+
+// completeCommand prints the candidates of the word being completed, it is
+// called by the completion scripts.
+type completeCommand struct {
+	root Command
+
+	ps      []provider.Provider
+	opts    []cfg.Option
+	newFunc NewConfiguratorFunc
+}
+
+func (*completeCommand) hidden() bool { return true }
+
+func (*completeCommand) WriteHelp(w io.Writer, _ IntrospectionOptions) (int, error) {
+	return io.WriteString(w, "Print the completion candidates")
+}
+
+func (*completeCommand) WriteSynopsis(io.Writer, IntrospectionOptions) (int, error) {
+	return 0, nil
+}
+
+func (cc *completeCommand) Run(ctx context.Context, cctx CommandContext) error {
+	var c completeConfig
+
+	if err := cctx.Configurator.Populate(ctx, &c); err != nil {
+		return err
+	}
+
+	words := cctx.Args
+
+	if len(words) > 0 && words[0] == "--" {
+		words = words[1:]
+	}
+
+	candidates, err := cc.complete(ctx, cctx, words, c.Current)
+
+	if err != nil {
+		return err
+	}
+
+	for _, c := range candidates {
+		if _, err := fmt.Fprintln(cctx.Stdout, c); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// completionState is the position in the command tree reached by the
+// words preceding the one being completed.
+type completionState struct {
+	cmd  Command
+	defs []CommandDefinition
+	args map[string]string
+
+	// values holds the values of the variadic arguments, joined in args.
+	values map[string][]string
+
+	factory setter.Factory
+
+	// base tells whether a command populating the baseConfig flags was
+	// crossed.
+	base bool
+}
+
+// unwrap descends the commands not consuming any argument.
+func (cs *completionState) unwrap() {
+	for {
+		bc, ok := cs.cmd.(*baseCommand)
+
+		if !ok {
+			return
+		}
+
+		cs.base = true
+		cs.cmd = bc.Command
+	}
+}
+
+// consume moves to the command designated by the positional argument
+// arg, it returns false if there is no such command.
+func (cs *completionState) consume(arg string) bool {
+	cs.unwrap()
+
+	switch tcmd := cs.cmd.(type) {
+	case ArgumentCommand:
+		// A variadic argument keeps completing its values.
+		if tcmd.Variadic {
+			vs := append(cs.values[tcmd.Variable], arg)
+
+			cs.values[tcmd.Variable] = vs
+			cs.args[tcmd.Variable] = joinValues(vs)
+
+			return true
+		}
+
+		cs.args[tcmd.Variable] = arg
+		cs.defs = append(cs.defs, tcmd.definition())
+		cs.cmd = tcmd.Command
+	case SubCommand:
+		name, next, ok := tcmd.lookup(arg)
+
+		if !ok {
+			return false
+		}
+
+		if tcmd.Variable != "" {
+			cs.args[tcmd.Variable] = name
+		}
+
+		cs.defs = append(cs.defs, tcmd.definition(cs.defs))
+		cs.cmd = next
+	}
+
+	return true
+}
+
+// flags returns the flags of the command, including the ones of the
+// commands wrapped by its arguments, which can be set before them.
+func (cs completionState) flags() ([]string, error) {
+	for {
+		cs.unwrap()
+
+		ac, ok := cs.cmd.(ArgumentCommand)
+
+		if !ok {
+			break
+		}
+
+		cs.defs = append(cs.defs, ac.definition())
+		cs.cmd = ac.Command
+	}
+
+	dc := definitionCollector{}
+
+	if _, err := cs.cmd.WriteHelp(
+		io.Discard,
+		IntrospectionOptions{Definitions: cs.defs, collector: &dc, factory: cs.factory},
+	); err != nil {
+		return nil, err
+	}
+
+	cfgs := dc.configs()
+
+	if cs.base {
+		cfgs = append(cfgs, &baseConfig{})
+	}
+
+	fc := *completion.DefaultFlagCollector
+
+	if cs.factory != nil {
+		fc.Factory = cs.factory
+	}
+
+	return fc.Collect(cfgs...)
+}
+
+func (cc *completeCommand) complete(ctx context.Context, cctx CommandContext, words []string, cur string) ([]string, error) {
+	if n := len(words); n > 0 {
+		last := words[n-1]
+
+		// The word being completed is the value of the previous flag.
+		if last != "--" && last[0] == '-' && !strings.Contains(last, "=") {
+			return nil, nil
+		}
+	}
+
+	cmds, flags := splitArgs(words)
+
+	cs := completionState{
+		cmd:     cc.root,
+		args:    make(map[string]string),
+		values:  make(map[string][]string),
+		factory: cctx.factory,
+	}
+
+	for _, arg := range cmds {
+		// The words following "--" are handed to the command as is.
+		if arg == "--" || !cs.consume(arg) {
+			return nil, nil
+		}
+	}
+
+	cs.unwrap()
+
+	if strings.HasPrefix(cur, "-") {
+		fs, err := cs.flags()
+
+		if err != nil {
+			return nil, err
+		}
+
+		return completion.Filter(fs, cur), nil
+	}
+
+	switch tcmd := cs.cmd.(type) {
+	case SubCommand:
+		return completion.Filter(tcmd.visibleCommands(), cur), nil
+	case ArgumentCommand:
+		if tcmd.Complete == nil {
+			return nil, nil
+		}
+
+		cctx.Configurator = newConfigurator(
+			cc.newFunc,
+			cc.opts,
+			cc.ps,
+			pflags.NewProvider(flags),
+			cs.args,
+		)
+		cctx.Definitions = cs.defs
+		cctx.Args = nil
+		cctx.args = cs.args
+
+		vs, err := tcmd.Complete(ctx, cctx, cur)
+
+		if err != nil {
+			return nil, err
+		}
+
+		return completion.Filter(vs, cur), nil
+	}
+
+	return nil, nil
+}
+
+// WithCompletionCommand adds a "completion" sub command printing the bash,
+// zsh or fish completion script of the app, e.g.
+// "source <(app completion bash)".
+func WithCompletionCommand() Option {
+	return func(o *options) { o.completion = true }
+}
@@ -0,0 +1,193 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type getConfig struct {
+	Namespace string `flag:"n,namespace"`
+	Output    string `flag:"o,output"`
+}
+
+func TestCompletion(t *testing.T) {
+	getCmd := ArgumentCommand{
+		Variable: "resource",
+		Command: StaticCommand{
+			Help:     HelpWriter(&getConfig{}),
+			Synopsis: SynopsisWriter(&getConfig{}),
+		},
+		Complete: func(ctx context.Context, cctx CommandContext, _ string) ([]string, error) {
+			var c getConfig
+
+			if err := cctx.Configurator.Populate(ctx, &c); err != nil {
+				return nil, err
+			}
+
+			if c.Namespace == "kube-system" {
+				return []string{"coredns", "kube-proxy"}, nil
+			}
+
+			return []string{"api", "web", "worker"}, nil
+		},
+	}
+
+	copyCmd := ArgumentCommand{
+		Variable: "src",
+		Variadic: true,
+		Command:  StaticCommand{},
+		Complete: func(ctx context.Context, cctx CommandContext, _ string) ([]string, error) {
+			var c struct {
+				Sources []string `arg:"src"`
+			}
+
+			if err := cctx.Configurator.Populate(ctx, &c); err != nil {
+				return nil, err
+			}
+
+			return c.Sources, nil
+		},
+	}
+
+	newRoot := func() SubCommand {
+		return SubCommand{
+			Commands: map[string]Command{
+				"get":    getCmd,
+				"delete": ArgumentCommand{Variable: "resource", Command: StaticCommand{}},
+				"copy":   copyCmd,
+				"debug":  StaticCommand{},
+			},
+			Metadata: map[string]CommandMetadata{
+				"get":   {Aliases: []string{"g"}},
+				"debug": {Hidden: true},
+			},
+		}
+	}
+
+	for _, tt := range []struct {
+		name string
+		args []string
+		out  []string
+	}{
+		{
+			name: "sub commands",
+			args: []string{completeCommandName, "--current=", "--"},
+			out:  []string{"completion", "copy", "delete", "get", "help", "version"},
+		},
+		{
+			name: "sub commands with prefix",
+			args: []string{completeCommandName, "--current=de", "--"},
+			out:  []string{"delete"},
+		},
+		{
+			name: "flags",
+			args: []string{completeCommandName, "--current=-", "--", "get"},
+			out: []string{
+				"--help",
+				"--log-level",
+				"--namespace",
+				"--output",
+				"--verbose",
+				"--version",
+				"-h",
+				"-n",
+				"-o",
+				"-v",
+			},
+		},
+		{
+			name: "flags with prefix",
+			args: []string{completeCommandName, "--current=--na", "--", "get"},
+			out:  []string{"--namespace"},
+		},
+		{
+			name: "argument",
+			args: []string{completeCommandName, "--current=w", "--", "get"},
+			out:  []string{"web", "worker"},
+		},
+		{
+			name: "argument of an alias",
+			args: []string{completeCommandName, "--current=a", "--", "g"},
+			out:  []string{"api"},
+		},
+		{
+			name: "argument using flags",
+			args: []string{completeCommandName, "--current=", "--", "get", "-n", "kube-system"},
+			out:  []string{"coredns", "kube-proxy"},
+		},
+		{
+			name: "variadic argument",
+			args: []string{completeCommandName, "--current=", "--", "copy", "a,b", `it's "c"`},
+			out:  []string{"a,b", `it's "c"`},
+		},
+		{
+			name: "flag value",
+			args: []string{completeCommandName, "--current=", "--", "get", "-n"},
+		},
+		{
+			name: "argument without hook",
+			args: []string{completeCommandName, "--current=", "--", "delete"},
+		},
+		{
+			name: "unknown command",
+			args: []string{completeCommandName, "--current=", "--", "create"},
+		},
+		{
+			name: "completed command",
+			args: []string{completeCommandName, "--current=", "--", "get", "api"},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				outBuf bytes.Buffer
+
+				a = NewApp(
+					WithName("cli-test"),
+					WithCommand(newRoot()),
+					WithCompletionCommand(),
+				)
+			)
+
+			a.args = tt.args
+
+			cctx := a.commandContext()
+			cctx.Stdout = &outBuf
+
+			err := a.cmd.Run(context.Background(), cctx)
+
+			assert.NoError(t, err)
+
+			var out []string
+
+			if s := strings.TrimSpace(outBuf.String()); s != "" {
+				out = strings.Split(s, "\n")
+			}
+
+			assert.Equal(t, tt.out, out)
+		})
+	}
+}
+
+func TestCompletionScript(t *testing.T) {
+	var (
+		outBuf bytes.Buffer
+
+		a = NewApp(
+			WithName("cli-test"),
+			WithCommand(SubCommand{}),
+			WithCompletionCommand(),
+		)
+	)
+
+	a.args = []string{"completion", "bash"}
+
+	cctx := a.commandContext()
+	cctx.Stdout = &outBuf
+
+	assert.NoError(t, a.cmd.Run(context.Background(), cctx))
+	assert.Contains(t, outBuf.String(), "complete -o default -F _cli_test_completion cli-test\n")
+}
@@ -0,0 +1,146 @@
+package cli
+
+import (
+	"context"
+	"io"
+	"strings"
+
+	"github.com/upfluence/errors"
+
+	"github.com/upfluence/cfg/internal/template"
+)
+
+type configTemplateConfig struct {
+	Format string `flag:"f,format" default:"yaml" help:"Template format (env, json, yaml), json templates are not commented"`
+}
+
+type configTemplateCommand struct {
+	root Command
+	tw   *template.Writer
+}
+
+func (ctc *configTemplateCommand) WriteHelp(w io.Writer, opts IntrospectionOptions) (int, error) {
+	if opts.Short {
+		return io.WriteString(w, "Print a configuration template of a command")
+	}
+
+	return writeHelp(
+		w,
+		opts.withDefinition(
+			CommandDefinition{
+				Args:    []string{"command"},
+				Configs: []interface{}{&configTemplateConfig{}},
+				arities: map[string]arity{
+					"command": {optional: true, variadic: true},
+				},
+			},
+		),
+	)
+}
+
+func (ctc *configTemplateCommand) WriteSynopsis(io.Writer, IntrospectionOptions) (int, error) {
+	return 0, nil
+}
+
+// resolve returns the command designated by the path along with the
+// definitions of its ancestors.
+func (ctc *configTemplateCommand) resolve(path []string) (Command, []CommandDefinition, error) {
+	var (
+		cmd  = ctc.root
+		defs []CommandDefinition
+	)
+
+	for {
+		switch tcmd := cmd.(type) {
+		case *baseCommand:
+			cmd = tcmd.Command
+			continue
+		case ArgumentCommand:
+			defs = append(defs, tcmd.definition())
+			cmd = tcmd.Command
+			continue
+		case SubCommand:
+			if len(path) == 0 {
+				return cmd, defs, nil
+			}
+
+			_, next, ok := tcmd.lookup(path[0])
+
+			if !ok {
+				return nil, nil, errors.Newf("unknown command %q", strings.Join(path, " "))
+			}
+
+			defs = append(defs, tcmd.definition(defs))
+			cmd = next
+			path = path[1:]
+
+			continue
+		}
+
+		if len(path) > 0 {
+			return nil, nil, errors.Newf("command has no sub command %q", path[0])
+		}
+
+		return cmd, defs, nil
+	}
+}
+
+func (ctc *configTemplateCommand) Run(ctx context.Context, cctx CommandContext) error {
+	var c configTemplateConfig
+
+	if ok, err := isHelpRequested(ctx, cctx); err != nil || ok {
+		if err == nil {
+			_, err = ctc.WriteHelp(cctx.Stderr, cctx.introspectionOptions())
+		}
+
+		return err
+	}
+
+	if err := cctx.Configurator.Populate(ctx, &c); err != nil {
+		return err
+	}
+
+	cmd, defs, err := ctc.resolve(cctx.Args)
+
+	if err != nil {
+		return err
+	}
+
+	dc := definitionCollector{}
+
+	if _, err := cmd.WriteHelp(
+		io.Discard,
+		IntrospectionOptions{Definitions: defs, collector: &dc, factory: cctx.factory},
+	); err != nil {
+		return errors.Wrap(err, "introspect command")
+	}
+
+	tw := *ctc.tw
+
+	if cctx.factory != nil {
+		tw.Factory = cctx.factory
+	}
+
+	_, err = tw.Write(cctx.Stdout, template.Format(c.Format), dc.configs()...)
+
+	return err
+}
+
+// WithConfigTemplateCommand adds a "config-template" sub command printing
+// a commented configuration template of the command designated by its
+// arguments, e.g. "app config-template --format env server start".
+func WithConfigTemplateCommand() Option {
+	return func(o *options) { o.configTemplate = true }
+}
+
+func (o *options) configTemplateCommand(root Command) *configTemplateCommand {
+	tw := *template.DefaultWriter
+
+	for _, p := range o.ps {
+		if p.StructTag() == "env" {
+			tw.Env = p
+		}
+	}
+
+	return &configTemplateCommand{root: root, tw: &tw}
+}
@@ -0,0 +1,120 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type serverConfig struct {
+	Port int    `flag:"port" env:"PORT" json:"port" yaml:"port" default:"8080" help:"Listening port"`
+	Host string `flag:"host" env:"HOST" json:"host" yaml:"host" required:"true"`
+}
+
+func TestConfigTemplateCommand(t *testing.T) {
+	serverCmd := ArgumentCommand{
+		Variable: "name",
+		Command: StaticCommand{
+			Help:     HelpWriter(&serverConfig{}),
+			Synopsis: SynopsisWriter(&serverConfig{}),
+		},
+	}
+
+	newRoot := func() SubCommand {
+		return SubCommand{
+			Commands: map[string]Command{
+				"server": SubCommand{Commands: map[string]Command{"start": serverCmd}},
+			},
+		}
+	}
+
+	for _, tt := range []struct {
+		name string
+		opts []Option
+		args []string
+
+		wantOut string
+		wantErr string
+	}{
+		{
+			name:    "not enabled",
+			args:    []string{"config-template", "server", "start"},
+			wantErr: `unknown command "config-template"`,
+		},
+		{
+			name: "yaml",
+			opts: []Option{WithConfigTemplateCommand()},
+			args: []string{"config-template", "server", "start"},
+			wantOut: "# Listening port\n" +
+				"# integer, default: 8080\n" +
+				"port: 8080\n" +
+				"# string, required\n" +
+				"host: null\n",
+		},
+		{
+			name: "env",
+			opts: []Option{WithConfigTemplateCommand()},
+			args: []string{"config-template", "-f", "env", "server", "start"},
+			wantOut: "# Listening port\n" +
+				"# integer, default: 8080\n" +
+				"PORT=8080\n\n" +
+				"# string, required\n" +
+				"# HOST=\n",
+		},
+		{
+			name:    "sub command",
+			opts:    []Option{WithConfigTemplateCommand()},
+			args:    []string{"config-template", "--format=json", "server"},
+			wantOut: "{}\n",
+		},
+		{
+			name:    "unknown command",
+			opts:    []Option{WithConfigTemplateCommand()},
+			args:    []string{"config-template", "server", "stop"},
+			wantErr: `unknown command "stop"`,
+		},
+		{
+			name:    "unknown format",
+			opts:    []Option{WithConfigTemplateCommand()},
+			args:    []string{"config-template", "-f", "toml", "server", "start"},
+			wantErr: `"toml": unknown template format`,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				outBuf bytes.Buffer
+				errBuf bytes.Buffer
+
+				a = NewApp(
+					append(
+						[]Option{WithName("cli-test"), WithCommand(newRoot())},
+						tt.opts...,
+					)...,
+				)
+			)
+
+			a.args = tt.args
+
+			cctx := a.commandContext()
+			cctx.Stdout = &outBuf
+			cctx.Stderr = &errBuf
+
+			err := a.cmd.Run(context.Background(), cctx)
+
+			assert.Equal(t, tt.wantOut, outBuf.String())
+
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+
+			if err != nil {
+				assert.Contains(t, err.Error(), tt.wantErr)
+			} else {
+				assert.Contains(t, errBuf.String(), tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,282 @@
+package cli
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/upfluence/errors"
+
+	"github.com/upfluence/cfg/internal/help"
+	pflags "github.com/upfluence/cfg/provider/flags"
+)
+
+type DocFormat string
+
+const (
+	MarkdownDocFormat DocFormat = "markdown"
+	ManDocFormat      DocFormat = "man"
+)
+
+var ErrUnknownDocFormat = errors.New("x/cli: unknown doc format")
+
+// DocPage is the reference page of a command of the app.
+type DocPage struct {
+	// Path holds the sub command names leading to the command.
+	Path     []string
+	Filename string
+	Content  []byte
+}
+
+// docCommand is a command of the tree as described by its reference page.
+type docCommand struct {
+	path []string
+
+	summary     string
+	description string
+	aliases     []string
+	deprecated  string
+	synopsis    string
+	options     []help.Field
+	commands    []*docCommand
+
+	parent *docCommand
+}
+
+func (dc *docCommand) name(app string) string {
+	return strings.Join(append([]string{app}, dc.path...), " ")
+}
+
+func (dc *docCommand) filename(app, ext string) string {
+	return strings.Join(append([]string{app}, dc.path...), "-") + ext
+}
+
+type docBuilder struct {
+	appName string
+	hw      *help.Writer
+
+	commands []*docCommand
+}
+
+// isDocumented tells whether the command gets a reference page, the built
+// in help and version commands are left out.
+func isDocumented(cmd Command) bool {
+	if bc, ok := cmd.(*baseCommand); ok {
+		cmd = bc.Command
+	}
+
+	switch cmd.(type) {
+	case *helpCommand, *versionCommand:
+		return false
+	}
+
+	return !isHidden(cmd)
+}
+
+func (db *docBuilder) build(cmd Command, parent *docCommand, path []string, defs []CommandDefinition, args map[string]string) (*docCommand, error) {
+	var base bool
+
+unwrap:
+	for {
+		switch tcmd := cmd.(type) {
+		case *baseCommand:
+			base = true
+			cmd = tcmd.Command
+		case ArgumentCommand:
+			defs = append(defs, tcmd.definition())
+			cmd = tcmd.Command
+		default:
+			break unwrap
+		}
+	}
+
+	dc := docCommand{path: path, parent: parent}
+	db.commands = append(db.commands, &dc)
+
+	col := definitionCollector{}
+	opts := IntrospectionOptions{
+		AppName:     db.appName,
+		Definitions: defs,
+		args:        args,
+		collector:   &col,
+		factory:     db.hw.Factory,
+	}
+
+	if _, err := cmd.WriteHelp(io.Discard, opts); err != nil {
+		return nil, errors.Wrap(err, "introspect command")
+	}
+
+	dc.summary = col.summary
+	dc.description = col.description
+
+	if dc.summary == "" {
+		var b strings.Builder
+
+		opts.Short = true
+
+		if _, err := cmd.WriteHelp(&b, opts); err != nil {
+			return nil, errors.Wrap(err, "introspect command")
+		}
+
+		if s := strings.TrimSpace(b.String()); !strings.HasPrefix(s, "usage:") {
+			dc.summary = s
+		}
+	}
+
+	var synopsis strings.Builder
+
+	opts.Definitions = col.defs
+
+	sc, ok := cmd.(SubCommand)
+
+	if ok {
+		opts.Definitions = append(defs[:len(defs):len(defs)], sc.definition(defs))
+	}
+
+	if _, err := writeSynopsis(&synopsis, opts); err != nil {
+		return nil, errors.Wrap(err, "write synopsis")
+	}
+
+	dc.synopsis = strings.TrimSpace(synopsis.String())
+
+	cfgs := col.configs()
+
+	if base {
+		cfgs = append(cfgs, &baseConfig{})
+	}
+
+	fs, err := db.hw.Fields(cfgs...)
+
+	if err != nil {
+		return nil, errors.Wrap(err, "list options")
+	}
+
+	dc.options = fs
+
+	if !ok {
+		return &dc, nil
+	}
+
+	def := sc.definition(defs)
+	ks := make([]string, 0, len(sc.Commands))
+
+	for k, cmd := range sc.Commands {
+		if isDocumented(cmd) && !sc.Metadata[k].Hidden {
+			ks = append(ks, k)
+		}
+	}
+
+	sort.Strings(ks)
+
+	for _, k := range ks {
+		cargs := make(map[string]string, len(args)+1)
+
+		for ak, av := range args {
+			cargs[ak] = av
+		}
+
+		cargs[def.Args[0]] = k
+
+		child, err := db.build(
+			sc.Commands[k],
+			&dc,
+			append(path[:len(path):len(path)], k),
+			append(defs[:len(defs):len(defs)], def),
+			cargs,
+		)
+
+		if err != nil {
+			return nil, errors.Wrapf(err, "command %q", k)
+		}
+
+		child.aliases = sc.Metadata[k].Aliases
+		child.deprecated = sc.Metadata[k].Deprecated
+
+		dc.commands = append(dc.commands, child)
+	}
+
+	return &dc, nil
+}
+
+// DocPages returns the reference pages of the commands of the app, one per
+// command of the tree, sub commands included.
+func (a *App) DocPages(f DocFormat) ([]DocPage, error) {
+	var render func(*bytes.Buffer, string, *docCommand)
+
+	ext := ".md"
+
+	switch f {
+	case MarkdownDocFormat:
+		render = renderMarkdown
+	case ManDocFormat:
+		render = renderMan
+		ext = ".1"
+	default:
+		return nil, errors.Wrapf(ErrUnknownDocFormat, "%q", f)
+	}
+
+	var (
+		name = filepath.Base(a.name)
+		db   = docBuilder{
+			appName: name,
+			hw: &help.Writer{
+				Providers: append(
+					a.ps[:len(a.ps):len(a.ps)],
+					pflags.NewProvider(nil),
+				),
+				Factory: a.factory,
+			},
+		}
+	)
+
+	if _, err := db.build(a.cmd, nil, nil, nil, make(map[string]string)); err != nil {
+		return nil, err
+	}
+
+	pages := make([]DocPage, 0, len(db.commands))
+
+	for _, dc := range db.commands {
+		var b bytes.Buffer
+
+		render(&b, name, dc)
+
+		pages = append(
+			pages,
+			DocPage{
+				Path:     dc.path,
+				Filename: dc.filename(name, ext),
+				Content:  b.Bytes(),
+			},
+		)
+	}
+
+	return pages, nil
+}
+
+// WriteDocs writes the reference pages of the app in the directory dir.
+func (a *App) WriteDocs(dir string, f DocFormat) error {
+	pages, err := a.DocPages(f)
+
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return errors.Wrap(err, "create directory")
+	}
+
+	for _, p := range pages {
+		if err := os.WriteFile(
+			filepath.Join(dir, p.Filename),
+			p.Content,
+			0644,
+		); err != nil {
+			return errors.Wrapf(err, "write %q", p.Filename)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,220 @@
+package cli
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/upfluence/cfg/internal/help"
+)
+
+func optionAttributes(f help.Field, quote func(string) string) []string {
+	var attrs []string
+
+	if f.Default != "" {
+		attrs = append(attrs, "default: "+quote(f.Default))
+	}
+
+	for _, r := range f.Rules {
+		attrs = append(attrs, r.String())
+	}
+
+	return attrs
+}
+
+func markdownCode(s string) string { return "`" + s + "`" }
+
+func renderMarkdown(b *bytes.Buffer, app string, dc *docCommand) {
+	fmt.Fprintf(b, "# %s\n", dc.name(app))
+
+	if dc.summary != "" {
+		fmt.Fprintf(b, "\n%s\n", dc.summary)
+	}
+
+	if dc.deprecated != "" {
+		fmt.Fprintf(b, "\n**Deprecated:** %s\n", dc.deprecated)
+	}
+
+	fmt.Fprintf(b, "\n## Synopsis\n\n```\n%s\n```\n", dc.synopsis)
+
+	if len(dc.aliases) > 0 {
+		fmt.Fprintf(b, "\nAliases: `%s`\n", strings.Join(dc.aliases, "`, `"))
+	}
+
+	if dc.description != "" {
+		fmt.Fprintf(b, "\n## Description\n\n%s\n", strings.TrimSpace(dc.description))
+	}
+
+	if len(dc.options) > 0 {
+		b.WriteString("\n## Options\n\n")
+
+		for _, o := range dc.options {
+			fmt.Fprintf(b, "- `%s` (%s)", o.Name, o.Type)
+
+			if o.Help != "" {
+				fmt.Fprintf(b, ": %s", o.Help)
+			}
+
+			b.WriteByte('\n')
+
+			for _, attr := range optionAttributes(o, markdownCode) {
+				fmt.Fprintf(b, "  - %s\n", attr)
+			}
+
+			for _, pk := range o.Keys {
+				fmt.Fprintf(b, "  - %s: `%s`", pk.StructTag, strings.Join(pk.Keys, "`, `"))
+
+				for _, k := range pk.Deprecated {
+					fmt.Fprintf(b, ", `%s` (deprecated)", k)
+				}
+
+				b.WriteByte('\n')
+			}
+		}
+	}
+
+	if len(dc.commands) > 0 {
+		b.WriteString("\n## Commands\n\n")
+
+		for _, c := range dc.commands {
+			fmt.Fprintf(b, "- [%s](%s)", c.name(app), c.filename(app, ".md"))
+
+			if c.summary != "" {
+				fmt.Fprintf(b, ": %s", c.summary)
+			}
+
+			b.WriteByte('\n')
+		}
+	}
+
+	if p := dc.parent; p != nil {
+		fmt.Fprintf(
+			b,
+			"\n## See Also\n\n- [%s](%s)\n",
+			p.name(app),
+			p.filename(app, ".md"),
+		)
+	}
+}
+
+var manEscaper = strings.NewReplacer(`\`, `\e`, "-", `\-`)
+
+// manEscape escapes the roff special characters, including the control
+// characters starting a line.
+func manEscape(s string) string {
+	lines := strings.Split(manEscaper.Replace(s), "\n")
+
+	for i, l := range lines {
+		if strings.HasPrefix(l, ".") || strings.HasPrefix(l, "'") {
+			lines[i] = `\&` + l
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+func renderMan(b *bytes.Buffer, app string, dc *docCommand) {
+	title := dc.filename(app, "")
+
+	fmt.Fprintf(
+		b,
+		".TH \"%s\" \"1\" \"\" \"%s\" \"%s Manual\"\n",
+		manEscape(strings.ToUpper(title)),
+		manEscape(app),
+		manEscape(app),
+	)
+
+	b.WriteString(".SH NAME\n")
+	b.WriteString(manEscape(title))
+
+	if dc.summary != "" {
+		b.WriteString(` \- `)
+		b.WriteString(manEscape(dc.summary))
+	}
+
+	b.WriteString("\n.SH SYNOPSIS\n.nf\n")
+	b.WriteString(manEscape(dc.synopsis))
+	b.WriteString("\n.fi\n")
+
+	if len(dc.aliases) > 0 {
+		fmt.Fprintf(b, ".PP\nAliases: %s\n", manEscape(strings.Join(dc.aliases, ", ")))
+	}
+
+	if dc.deprecated != "" {
+		fmt.Fprintf(b, ".SH DEPRECATED\n%s\n", manEscape(dc.deprecated))
+	}
+
+	if dc.description != "" {
+		b.WriteString(".SH DESCRIPTION\n")
+		b.WriteString(
+			strings.ReplaceAll(
+				manEscape(strings.TrimSpace(dc.description)),
+				"\n\n",
+				"\n.PP\n",
+			),
+		)
+		b.WriteByte('\n')
+	}
+
+	if len(dc.options) > 0 {
+		b.WriteString(".SH OPTIONS\n")
+
+		for _, o := range dc.options {
+			fmt.Fprintf(b, ".TP\n.B %s\n(%s)", manEscape(o.Name), manEscape(o.Type))
+
+			if o.Help != "" {
+				b.WriteString(" ")
+				b.WriteString(manEscape(o.Help))
+			}
+
+			b.WriteByte('\n')
+
+			for _, attr := range optionAttributes(o, manEscape) {
+				fmt.Fprintf(b, ".br\n%s\n", attr)
+			}
+
+			for _, pk := range o.Keys {
+				fmt.Fprintf(b, ".br\n%s\n", manEscape(pk.String()))
+			}
+		}
+	}
+
+	if len(dc.commands) > 0 {
+		b.WriteString(".SH COMMANDS\n")
+
+		for _, c := range dc.commands {
+			fmt.Fprintf(b, ".TP\n.B %s\n", manEscape(c.name(app)))
+
+			if c.summary != "" {
+				b.WriteString(manEscape(c.summary))
+				b.WriteByte('\n')
+			}
+		}
+	}
+
+	var seeAlso []string
+
+	if p := dc.parent; p != nil {
+		seeAlso = append(seeAlso, p.filename(app, ""))
+	}
+
+	for _, c := range dc.commands {
+		seeAlso = append(seeAlso, c.filename(app, ""))
+	}
+
+	if len(seeAlso) == 0 {
+		return
+	}
+
+	b.WriteString(".SH SEE ALSO\n")
+
+	for i, p := range seeAlso {
+		if i > 0 {
+			b.WriteString(",\n")
+		}
+
+		fmt.Fprintf(b, `\fB%s\fR(1)`, manEscape(p))
+	}
+
+	b.WriteByte('\n')
+}
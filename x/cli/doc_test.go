@@ -0,0 +1,239 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/upfluence/cfg"
+)
+
+type startConfig struct {
+	Port int `flag:"p,port" env:"PORT" default:"8080" help:"Listening port" min:"1"`
+}
+
+func newDocApp() *App {
+	return NewApp(
+		WithName("/usr/bin/myapp"),
+		WithCommand(
+			SubCommand{
+				Commands: map[string]Command{
+					"server": SubCommand{
+						ShortHelp: StaticString("Manage the server"),
+						Commands: map[string]Command{
+							"start": ArgumentCommand{
+								Variable: "name",
+								Command: StaticCommand{
+									Help: EnhancedHelp{
+										Short:  "Start the server",
+										Long:   "Start the server.\n\n.Listens on the given port.",
+										Config: &startConfig{},
+									}.WriteHelp,
+								},
+							},
+						},
+						Metadata: map[string]CommandMetadata{
+							"start": {Aliases: []string{"run"}},
+						},
+					},
+					"debug": StaticCommand{Help: StaticString("Debug the app")},
+				},
+				Metadata: map[string]CommandMetadata{"debug": {Hidden: true}},
+			},
+		),
+	)
+}
+
+const baseOptionsMarkdown = "- `Help` (bool): Display this message\n" +
+	"  - env: `HELP`\n" +
+	"  - flag: `-h`, `--help`\n" +
+	"- `Version` (bool): Display the app version\n" +
+	"  - env: `VERSION`\n" +
+	"  - flag: `-v`, `--version`\n" +
+	"- `Verbose` (bool): Enable verbose logging\n" +
+	"  - env: `VERBOSE`\n" +
+	"  - flag: `--verbose`\n" +
+	"- `LogLevel` (cli.logLevel): Set the log level (debug, info, notice, warning, error)\n" +
+	"  - env: `LOGLEVEL`\n" +
+	"  - flag: `--log-level`\n"
+
+func TestDocPagesMarkdown(t *testing.T) {
+	pages, err := newDocApp().DocPages(MarkdownDocFormat)
+	require.NoError(t, err)
+
+	assert.Equal(
+		t,
+		[]DocPage{
+			{
+				Filename: "myapp.md",
+				Content: []byte(
+					"# myapp\n\n" +
+						"## Synopsis\n\n" +
+						"```\nmyapp <arg_1>\n```\n\n" +
+						"## Commands\n\n" +
+						"- [myapp server](myapp-server.md): Manage the server\n",
+				),
+			},
+			{
+				Path:     []string{"server"},
+				Filename: "myapp-server.md",
+				Content: []byte(
+					"# myapp server\n\n" +
+						"Manage the server\n\n" +
+						"## Synopsis\n\n" +
+						"```\nmyapp server <arg_2>\n```\n\n" +
+						"## Commands\n\n" +
+						"- [myapp server start](myapp-server-start.md): Start the server\n\n" +
+						"## See Also\n\n" +
+						"- [myapp](myapp.md)\n",
+				),
+			},
+			{
+				Path:     []string{"server", "start"},
+				Filename: "myapp-server-start.md",
+				Content: []byte(
+					"# myapp server start\n\n" +
+						"Start the server\n\n" +
+						"## Synopsis\n\n" +
+						"```\nmyapp server start <name> [-p, --port]\n```\n\n" +
+						"Aliases: `run`\n\n" +
+						"## Description\n\n" +
+						"Start the server.\n\n.Listens on the given port.\n\n" +
+						"## Options\n\n" +
+						"- `Port` (integer): Listening port\n" +
+						"  - default: `8080`\n" +
+						"  - min: 1\n" +
+						"  - env: `PORT`\n" +
+						"  - flag: `-p`, `--port`\n" +
+						baseOptionsMarkdown +
+						"\n## See Also\n\n" +
+						"- [myapp server](myapp-server.md)\n",
+				),
+			},
+		},
+		pages,
+	)
+}
+
+func TestDocPagesMan(t *testing.T) {
+	pages, err := newDocApp().DocPages(ManDocFormat)
+	require.NoError(t, err)
+	require.Len(t, pages, 3)
+
+	assert.Equal(t, "myapp-server.1", pages[1].Filename)
+	assert.Equal(
+		t,
+		".TH \"MYAPP\\-SERVER\" \"1\" \"\" \"myapp\" \"myapp Manual\"\n"+
+			".SH NAME\n"+
+			"myapp\\-server \\- Manage the server\n"+
+			".SH SYNOPSIS\n"+
+			".nf\nmyapp server <arg_2>\n.fi\n"+
+			".SH COMMANDS\n"+
+			".TP\n.B myapp server start\nStart the server\n"+
+			".SH SEE ALSO\n"+
+			"\\fBmyapp\\fR(1),\n\\fBmyapp\\-server\\-start\\fR(1)\n",
+		string(pages[1].Content),
+	)
+
+	assert.Contains(
+		t,
+		string(pages[2].Content),
+		".SH DESCRIPTION\nStart the server.\n.PP\n\\&.Listens on the given port.\n"+
+			".SH OPTIONS\n"+
+			".TP\n.B Port\n(integer) Listening port\n"+
+			".br\ndefault: 8080\n.br\nmin: 1\n.br\nenv: PORT\n.br\nflag: \\-p, \\-\\-port\n",
+	)
+}
+
+func TestDocPagesUnknownFormat(t *testing.T) {
+	_, err := newDocApp().DocPages("html")
+	assert.ErrorIs(t, err, ErrUnknownDocFormat)
+}
+
+func TestWriteDocs(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "docs")
+
+	require.NoError(t, newDocApp().WriteDocs(dir, ManDocFormat))
+
+	es, err := os.ReadDir(dir)
+	require.NoError(t, err)
+
+	var names []string
+
+	for _, e := range es {
+		names = append(names, e.Name())
+	}
+
+	assert.Equal(t, []string{"myapp-server-start.1", "myapp-server.1", "myapp.1"}, names)
+}
+
+type hostPort struct {
+	Host string
+	Port string
+}
+
+func parseHostPort(v string) (hostPort, error) {
+	h, p, err := net.SplitHostPort(v)
+
+	return hostPort{Host: h, Port: p}, err
+}
+
+type proxyConfig struct {
+	Upstream hostPort `flag:"upstream" help:"Upstream address"`
+}
+
+func TestParsersIntrospection(t *testing.T) {
+	newApp := func(args ...string) *App {
+		return NewApp(
+			WithName("myapp"),
+			WithArgs(args),
+			WithConfiguratorOptions(
+				cfg.WithParsers(cfg.WithParser("host:port", parseHostPort)),
+			),
+			WithCommand(
+				SubCommand{
+					Commands: map[string]Command{
+						"proxy": StaticCommand{
+							Help:     HelpWriter(&proxyConfig{}),
+							Synopsis: SynopsisWriter(&proxyConfig{}),
+						},
+					},
+				},
+			),
+		)
+	}
+
+	ps, err := newApp().DocPages(MarkdownDocFormat)
+	require.NoError(t, err)
+
+	var doc string
+
+	for _, p := range ps {
+		doc += string(p.Content)
+	}
+
+	assert.Contains(t, doc, "- `Upstream` (host:port): Upstream address\n")
+	assert.NotContains(t, doc, "upstream.host")
+
+	var (
+		stderr bytes.Buffer
+
+		a    = newApp("proxy", "-h")
+		cctx = a.commandContext()
+	)
+
+	cctx.Stdout = &bytes.Buffer{}
+	cctx.Stderr = &stderr
+
+	require.NoError(t, a.cmd.Run(context.Background(), cctx))
+
+	assert.Contains(t, stderr.String(), " [--upstream] \n")
+	assert.Contains(t, stderr.String(), "Upstream: host:port Upstream address")
+	assert.NotContains(t, stderr.String(), "upstream.host")
+}
@@ -3,14 +3,39 @@ package cli
 import (
 	"fmt"
 	"io"
+	"strings"
 
 	"github.com/upfluence/cfg/internal/help"
+	"github.com/upfluence/cfg/internal/setter"
 	"github.com/upfluence/cfg/internal/synopsis"
 )
 
 type CommandDefinition struct {
 	Args    []string
 	Configs []interface{}
+
+	// arities holds the arity of the Args accepting no or several values.
+	arities map[string]arity
+}
+
+type arity struct {
+	optional bool
+	variadic bool
+}
+
+// format renders the argument name in a synopsis: <x>, [x], <x>... or
+// [x...].
+func (a arity) format(arg string) string {
+	switch {
+	case a.optional && a.variadic:
+		return fmt.Sprintf("[%s...]", arg)
+	case a.optional:
+		return fmt.Sprintf("[%s]", arg)
+	case a.variadic:
+		return fmt.Sprintf("<%s>...", arg)
+	}
+
+	return fmt.Sprintf("<%s>", arg)
 }
 
 type IntrospectionOptions struct {
@@ -18,15 +43,52 @@ type IntrospectionOptions struct {
 	Definitions []CommandDefinition
 	Short       bool
 
-	args map[string]string
+	args      map[string]string
+	collector *definitionCollector
+
+	// factory is built from the parser options of the app, the default
+	// one is used when it is nil.
+	factory setter.Factory
+}
+
+// definitionCollector records the definitions a command introspects, to
+// find out the configs it populates, along with its descriptions.
+type definitionCollector struct {
+	defs []CommandDefinition
+
+	summary     string
+	description string
+}
+
+func (dc *definitionCollector) describe(summary, description string) {
+	if dc != nil && dc.summary == "" && dc.description == "" {
+		dc.summary = summary
+		dc.description = description
+	}
 }
 
-func (io IntrospectionOptions) argName(arg string) string {
+func (dc *definitionCollector) record(defs []CommandDefinition) {
+	if dc != nil && len(defs) >= len(dc.defs) {
+		dc.defs = defs
+	}
+}
+
+func (dc *definitionCollector) configs() []interface{} {
+	var cfgs []interface{}
+
+	for _, def := range dc.defs {
+		cfgs = append(cfgs, def.Configs...)
+	}
+
+	return cfgs
+}
+
+func (io IntrospectionOptions) argName(def CommandDefinition, arg string) string {
 	if v, ok := io.args[arg]; ok {
 		return v
 	}
 
-	return fmt.Sprintf("<%s>", arg)
+	return def.arities[arg].format(arg)
 }
 
 func (io IntrospectionOptions) withDefinition(def CommandDefinition) IntrospectionOptions {
@@ -35,15 +97,47 @@ func (io IntrospectionOptions) withDefinition(def CommandDefinition) Introspecti
 		Definitions: append(io.Definitions, def),
 		Short:       io.Short,
 		args:        io.args,
+		collector:   io.collector,
+		factory:     io.factory,
 	}
 }
 
+func (io IntrospectionOptions) helpWriter() *help.Writer {
+	if io.factory == nil {
+		return help.DefaultWriter
+	}
+
+	w := *help.DefaultWriter
+	w.Factory = io.factory
+
+	return &w
+}
+
+func (io IntrospectionOptions) synopsisWriter() *synopsis.Writer {
+	if io.factory == nil {
+		return synopsis.DefaultWriter
+	}
+
+	w := *synopsis.DefaultWriter
+	w.Factory = io.factory
+
+	return &w
+}
+
 type IntrospectionFunc func(io.Writer, IntrospectionOptions) (int, error)
 
 func StaticString(v string) IntrospectionFunc {
+	summary, _, multiline := strings.Cut(v, "\n")
+
 	return func(w io.Writer, opts IntrospectionOptions) (int, error) {
 		var n int
 
+		if multiline {
+			opts.collector.describe(summary, v)
+		} else {
+			opts.collector.describe(v, "")
+		}
+
 		if !opts.Short {
 			nn, err := writeUsage(w, opts)
 			n += nn
@@ -115,11 +209,13 @@ func writeHelp(w io.Writer, opts IntrospectionOptions) (int, error) {
 func writeOptions(w io.Writer, opts IntrospectionOptions) (int, error) {
 	var cfgs []interface{}
 
+	opts.collector.record(opts.Definitions)
+
 	for _, def := range opts.Definitions {
 		cfgs = append(cfgs, def.Configs...)
 	}
 
-	return help.DefaultWriter.Write(w, cfgs...)
+	return opts.helpWriter().Write(w, cfgs...)
 }
 
 func SynopsisWriter(in interface{}) IntrospectionFunc {
@@ -134,6 +230,8 @@ func SynopsisWriter(in interface{}) IntrospectionFunc {
 func writeSynopsis(w io.Writer, opts IntrospectionOptions) (int, error) {
 	var n int
 
+	opts.collector.record(opts.Definitions)
+
 	if opts.AppName != "" {
 		nn, err := fmt.Fprintf(w, "%s ", opts.AppName)
 		n += nn
@@ -143,9 +241,11 @@ func writeSynopsis(w io.Writer, opts IntrospectionOptions) (int, error) {
 		}
 	}
 
+	sw := opts.synopsisWriter()
+
 	for _, def := range opts.Definitions {
 		for _, arg := range def.Args {
-			nn, err := fmt.Fprintf(w, "%s ", opts.argName(arg))
+			nn, err := fmt.Fprintf(w, "%s ", opts.argName(def, arg))
 			n += nn
 
 			if err != nil {
@@ -154,7 +254,7 @@ func writeSynopsis(w io.Writer, opts IntrospectionOptions) (int, error) {
 		}
 
 		for _, cfg := range def.Configs {
-			nn, err := synopsis.DefaultWriter.Write(w, cfg)
+			nn, err := sw.Write(w, cfg)
 			n += nn
 
 			if err != nil {
@@ -190,6 +290,8 @@ func (eh EnhancedHelp) wrapOptions(opts IntrospectionOptions) IntrospectionOptio
 }
 
 func (eh EnhancedHelp) WriteHelp(w io.Writer, opts IntrospectionOptions) (int, error) {
+	opts.collector.describe(eh.Short, eh.Long)
+
 	if opts.Short {
 		return io.WriteString(w, eh.Short)
 	}
@@ -52,6 +52,10 @@ type options struct {
 
 	version string
 
+	configTemplate bool
+	completion     bool
+	strictFlags    bool
+
 	stdin  io.Reader
 	stdout io.Writer
 	stderr io.Writer
@@ -87,6 +91,20 @@ func (o *options) command() Command {
 		if _, ok := scmd.Commands["help"]; !ok {
 			scmd.Commands["help"] = &helpCommand{cmd: cmd}
 		}
+
+		if _, ok := scmd.Commands["config-template"]; o.configTemplate && !ok {
+			scmd.Commands["config-template"] = o.configTemplateCommand(cmd)
+		}
+
+		if _, ok := scmd.Commands["completion"]; o.completion && !ok {
+			scmd.Commands["completion"] = &completionCommand{name: o.name}
+			scmd.Commands[completeCommandName] = &completeCommand{
+				root:    cmd,
+				ps:      o.ps,
+				opts:    o.opts,
+				newFunc: o.newFunc,
+			}
+		}
 	}
 
 	return cmd
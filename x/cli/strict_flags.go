@@ -0,0 +1,107 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+
+	"github.com/upfluence/errors"
+
+	"github.com/upfluence/cfg"
+	"github.com/upfluence/cfg/internal/suggest"
+	pflags "github.com/upfluence/cfg/provider/flags"
+)
+
+// UnknownFlagError is returned in strict flags mode when a flag is read by
+// no config of the command.
+type UnknownFlagError struct {
+	Flag        string
+	Suggestions []string
+}
+
+func (e *UnknownFlagError) Error() string {
+	if len(e.Suggestions) == 0 {
+		return fmt.Sprintf("unknown flag %q", e.Flag)
+	}
+
+	return fmt.Sprintf("unknown flag %q, did you mean %q?", e.Flag, e.Suggestions[0])
+}
+
+// WithStrictFlags makes the commands fail on the flags that none of their
+// configs reads, instead of ignoring them.
+func WithStrictFlags() Option {
+	return func(o *options) { o.strictFlags = true }
+}
+
+func flagName(k string) string {
+	if len(k) == 1 {
+		return "-" + k
+	}
+
+	return "--" + k
+}
+
+// checkFlags populates throwaway copies of the configs of cmd, tracking
+// the flags they read, and reports the first flag none of them read.
+func checkFlags(ctx context.Context, cctx CommandContext, cmd Command) error {
+	var dc definitionCollector
+
+	opts := cctx.introspectionOptions()
+	opts.collector = &dc
+
+	if _, err := cmd.WriteHelp(io.Discard, opts); err != nil {
+		return errors.Wrap(err, "introspect command")
+	}
+
+	var (
+		kt = cfg.NewKeyTracker()
+		c  = cctx.Configurator.WithOptions(
+			cfg.TrackKeys(kt),
+			cfg.CollectErrors,
+			// The deprecated keys are reported when the command runs.
+			cfg.OnDeprecatedKey(nil),
+		)
+	)
+
+	for _, in := range append(dc.configs(), &baseConfig{}) {
+		t := reflect.TypeOf(in)
+
+		if t == nil || t.Kind() != reflect.Ptr {
+			continue
+		}
+
+		// The population errors are reported when the command runs.
+		_ = c.Populate(ctx, reflect.New(t.Elem()).Interface())
+	}
+
+	var (
+		known      = make(map[string]struct{})
+		candidates []string
+	)
+
+	for _, k := range kt.Keys(pflags.StructTag) {
+		k = strings.ToLower(k)
+
+		if _, ok := known[k]; !ok {
+			known[k] = struct{}{}
+			candidates = append(candidates, flagName(k))
+		}
+	}
+
+	for _, n := range cctx.flags.Names() {
+		k := strings.ToLower(n)
+
+		if _, ok := known[k]; ok {
+			continue
+		}
+
+		return &UnknownFlagError{
+			Flag:        flagName(n),
+			Suggestions: suggest.Closest(flagName(k), candidates),
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,122 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type strictConfig struct {
+	Port      int                       `flag:"p,port" deprecated:"flag=listen"`
+	Databases map[string]strictDBConfig `flag:"databases"`
+}
+
+type strictDBConfig struct {
+	Host string `flag:"host"`
+}
+
+func TestStrictFlags(t *testing.T) {
+	cmd := StaticCommand{
+		Help: HelpWriter(&strictConfig{}),
+		Execute: func(ctx context.Context, cctx CommandContext) error {
+			var c strictConfig
+
+			return cctx.Configurator.Populate(ctx, &c)
+		},
+	}
+
+	for _, tt := range []struct {
+		name       string
+		opts       []Option
+		args       []string
+		errFn      assert.ErrorAssertionFunc
+		errMsg     string
+		wantStderr string
+	}{
+		{
+			name:  "lenient",
+			args:  []string{"--prot", "80"},
+			errFn: assert.NoError,
+		},
+		{
+			name:  "known flags",
+			opts:  []Option{WithStrictFlags()},
+			args:  []string{"-p", "80", "--verbose", "--databases.main.host=h"},
+			errFn: assert.NoError,
+		},
+		{
+			name:  "mixed case flags",
+			opts:  []Option{WithStrictFlags()},
+			args:  []string{"--Port", "80", "--Databases.main.HOST=h"},
+			errFn: assert.NoError,
+		},
+		{
+			name:   "mixed case typo",
+			opts:   []Option{WithStrictFlags()},
+			args:   []string{"--Prot", "80"},
+			errFn:  assert.Error,
+			errMsg: `unknown flag "--Prot", did you mean "--port"?`,
+		},
+		{
+			name:       "deprecated flag",
+			opts:       []Option{WithStrictFlags()},
+			args:       []string{"--listen", "80"},
+			errFn:      assert.NoError,
+			wantStderr: "warning: flag: key --listen of int.Port is deprecated, use -p or --port instead\n",
+		},
+		{
+			name:   "typo",
+			opts:   []Option{WithStrictFlags()},
+			args:   []string{"--prot", "80"},
+			errFn:  assert.Error,
+			errMsg: `unknown flag "--prot", did you mean "--port"?`,
+		},
+		{
+			name:   "unknown",
+			opts:   []Option{WithStrictFlags()},
+			args:   []string{"--foo"},
+			errFn:  assert.Error,
+			errMsg: `unknown flag "--foo"`,
+		},
+		{
+			name:   "unknown map field",
+			opts:   []Option{WithStrictFlags()},
+			args:   []string{"--databases.main.hots=h"},
+			errFn:  assert.Error,
+			errMsg: `unknown flag "--databases.main.hots", did you mean "--databases.main.host"?`,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			var stderr bytes.Buffer
+
+			a := NewApp(
+				append(
+					[]Option{
+						WithName("cli-test"),
+						WithCommand(cmd),
+						WithStderr(&stderr),
+					},
+					tt.opts...,
+				)...,
+			)
+
+			a.args = tt.args
+
+			cctx := a.commandContext()
+			cctx.Stdout = &bytes.Buffer{}
+			cctx.Stderr = &bytes.Buffer{}
+
+			err := a.cmd.Run(context.Background(), cctx)
+
+			tt.errFn(t, err)
+
+			if tt.errMsg != "" {
+				assert.EqualError(t, err, tt.errMsg)
+			}
+
+			assert.Equal(t, tt.wantStderr, stderr.String())
+		})
+	}
+}
@@ -5,15 +5,77 @@ import (
 	"fmt"
 	"io"
 	"sort"
+	"strings"
 	"text/tabwriter"
+
+	"github.com/upfluence/cfg/internal/suggest"
 )
 
+// hiddenCommand is implemented by the commands left out of the list of
+// sub commands.
+type hiddenCommand interface {
+	hidden() bool
+}
+
+func isHidden(cmd Command) bool {
+	hc, ok := cmd.(hiddenCommand)
+
+	return ok && hc.hidden()
+}
+
+// CommandMetadata describes how a sub command is listed and invoked.
+type CommandMetadata struct {
+	// Aliases are alternative names running the command.
+	Aliases []string
+
+	// Hidden leaves the command out of the list of sub commands, it can
+	// still be run.
+	Hidden bool
+
+	// Deprecated, when not empty, marks the command as deprecated, the
+	// message is printed every time the command is run.
+	Deprecated string
+
+	// Group is the heading the command is listed under, the commands
+	// without group are listed first.
+	Group string
+}
+
 type SubCommand struct {
 	Variable string
 
 	ShortHelp IntrospectionFunc
 
 	Commands map[string]Command
+
+	// Metadata holds the metadata of the commands, by command name.
+	Metadata map[string]CommandMetadata
+}
+
+// lookup returns the name and the command matching the given name or
+// alias.
+func (sc SubCommand) lookup(name string) (string, Command, bool) {
+	if cmd, ok := sc.Commands[name]; ok {
+		return name, cmd, true
+	}
+
+	for k, md := range sc.Metadata {
+		for _, alias := range md.Aliases {
+			if alias != name {
+				continue
+			}
+
+			cmd, ok := sc.Commands[k]
+
+			return k, cmd, ok
+		}
+	}
+
+	return "", nil, false
+}
+
+func (sc SubCommand) isHidden(k string) bool {
+	return sc.Metadata[k].Hidden || isHidden(sc.Commands[k])
 }
 
 func (sc SubCommand) variable(defs []CommandDefinition) string {
@@ -104,47 +166,122 @@ func (sc SubCommand) WriteHelp(w io.Writer, opts IntrospectionOptions) (int, err
 	return n, err
 }
 
-func (sc SubCommand) WriteSynopsis(w io.Writer, opts IntrospectionOptions) (int, error) {
-	if opts.Short {
-		return fmt.Fprintf(w, "<%s>", sc.variable(opts.Definitions))
+// visibleCommands returns the sorted names of the commands that are not
+// hidden.
+func (sc SubCommand) visibleCommands() []string {
+	ks := make([]string, 0, len(sc.Commands))
+
+	for k := range sc.Commands {
+		if !sc.isHidden(k) {
+			ks = append(ks, k)
+		}
 	}
 
-	var (
-		n int
+	sort.Strings(ks)
 
-		tw = tabwriter.NewWriter(w, 4, 4, 2, ' ', tabwriter.TabIndent)
-		ks = make([]string, 0, len(sc.Commands))
-	)
+	return ks
+}
 
-	opts = IntrospectionOptions{Short: true}
+// commandNames returns the names and aliases of the visible commands, the
+// candidates suggested for a mistyped command.
+func (sc SubCommand) commandNames() []string {
+	var ks []string
 
-	for k := range sc.Commands {
+	for _, k := range sc.visibleCommands() {
 		ks = append(ks, k)
+		ks = append(ks, sc.Metadata[k].Aliases...)
 	}
 
-	sort.Strings(ks)
+	return ks
+}
 
-	for _, k := range ks {
-		cmd := sc.Commands[k]
-		nn, err := fmt.Fprintf(tw, "\t%s  \t  ", k)
-		n += nn
+// commandGroups returns the visible commands by group, the commands
+// without group first and then the groups in alphabetical order.
+func (sc SubCommand) commandGroups() ([]string, map[string][]string) {
+	var (
+		groups []string
+		cmds   = make(map[string][]string)
+	)
 
-		if err != nil {
-			return n, err
+	for _, k := range sc.visibleCommands() {
+		g := sc.Metadata[k].Group
+
+		if _, ok := cmds[g]; !ok && g != "" {
+			groups = append(groups, g)
 		}
 
-		nn, err = cmd.WriteHelp(tw, opts)
+		cmds[g] = append(cmds[g], k)
+	}
+
+	sort.Strings(groups)
+
+	return append([]string{""}, groups...), cmds
+}
+
+func (sc SubCommand) writeCommand(w io.Writer, k string, opts IntrospectionOptions) (int, error) {
+	name := strings.Join(append([]string{k}, sc.Metadata[k].Aliases...), ", ")
+
+	n, err := fmt.Fprintf(w, "\t%s  \t  ", name)
+
+	if err != nil {
+		return n, err
+	}
+
+	nn, err := sc.Commands[k].WriteHelp(
+		w,
+		IntrospectionOptions{Short: true, factory: opts.factory},
+	)
+	n += nn
+
+	if err != nil {
+		return n, err
+	}
+
+	if sc.Metadata[k].Deprecated != "" {
+		nn, err = io.WriteString(w, " (deprecated)")
 		n += nn
 
 		if err != nil {
 			return n, err
 		}
+	}
 
-		nn, err = io.WriteString(tw, "\n")
-		n += nn
+	nn, err = io.WriteString(w, "\n")
+	n += nn
 
-		if err != nil {
-			return n, err
+	return n, err
+}
+
+func (sc SubCommand) WriteSynopsis(w io.Writer, opts IntrospectionOptions) (int, error) {
+	if opts.Short {
+		return fmt.Fprintf(w, "<%s>", sc.variable(opts.Definitions))
+	}
+
+	var (
+		n int
+
+		tw = tabwriter.NewWriter(w, 4, 4, 2, ' ', tabwriter.TabIndent)
+
+		groups, cmds = sc.commandGroups()
+	)
+
+	for _, g := range groups {
+		if g != "" {
+			nn, err := fmt.Fprintf(tw, "\n%s:\n", g)
+			n += nn
+
+			if err != nil {
+				return n, err
+			}
+		}
+
+		for _, k := range cmds[g] {
+			nn, err := sc.writeCommand(tw, k, opts)
+			n += nn
+
+			if err != nil {
+				return n, err
+			}
 		}
 	}
 
@@ -160,13 +297,9 @@ func (sc SubCommand) Run(ctx context.Context, cctx CommandContext) error {
 	if len(cctx.Args) > 0 {
 		cmdKey = cctx.Args[0]
 		args = cctx.Args[1:]
-
-		if sc.Variable != "" {
-			cctx.args[sc.Variable] = cmdKey
-		}
 	}
 
-	cmd, ok := sc.Commands[cmdKey]
+	name, cmd, ok := sc.lookup(cmdKey)
 
 	if !ok {
 		if cmdKey == "" {
@@ -182,10 +315,19 @@ func (sc SubCommand) Run(ctx context.Context, cctx CommandContext) error {
 			}
 		}
 
+		var suggestion string
+
+		if cmdKey != "" {
+			if ss := suggest.Closest(cmdKey, sc.commandNames()); len(ss) > 0 {
+				suggestion = fmt.Sprintf(" did you mean %q?", ss[0])
+			}
+		}
+
 		if _, err := fmt.Fprintf(
 			cctx.Stderr,
-			"unknown command %q, available commands:\n",
+			"unknown command %q,%s available commands:\n",
 			cmdKey,
+			suggestion,
 		); err != nil {
 			return err
 		}
@@ -195,6 +337,21 @@ func (sc SubCommand) Run(ctx context.Context, cctx CommandContext) error {
 		return err
 	}
 
+	if sc.Variable != "" {
+		cctx.args[sc.Variable] = name
+	}
+
+	if msg := sc.Metadata[name].Deprecated; msg != "" {
+		if _, err := fmt.Fprintf(
+			cctx.Stderr,
+			"command %q is deprecated: %s\n",
+			name,
+			msg,
+		); err != nil {
+			return err
+		}
+	}
+
 	cctx.Definitions = append(cctx.Definitions, sc.definition(cctx.Definitions))
 	cctx.Args = args
 
@@ -0,0 +1,527 @@
+package dump
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/upfluence/errors"
+	"gopkg.in/yaml.v3"
+
+	"github.com/upfluence/cfg/internal/redact"
+	"github.com/upfluence/cfg/internal/reflectutil"
+	"github.com/upfluence/cfg/internal/setter"
+	"github.com/upfluence/cfg/internal/walker"
+	"github.com/upfluence/cfg/provider"
+	pjson "github.com/upfluence/cfg/provider/json"
+	pyaml "github.com/upfluence/cfg/provider/yaml"
+)
+
+var ErrConflictingKeys = errors.New("x/dump: a key is both a value and a parent of other keys")
+
+type options struct {
+	factory          setter.Factory
+	ignoreMissingTag bool
+	includeSecrets   bool
+}
+
+type Option func(*options)
+
+// IncludeSecrets dumps the values of the fields flagged with the secret
+// tag instead of a placeholder.
+func IncludeSecrets(o *options) { o.includeSecrets = true }
+
+// IgnoreMissingTag skips the fields that do not carry the tag of the
+// provider, like the cfg option of the same name.
+func IgnoreMissingTag(o *options) { o.ignoreMissingTag = true }
+
+func newOptions(opts []Option) *options {
+	o := options{factory: setter.DefaultFactory}
+
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	return &o
+}
+
+// entry is a value of the struct along with the key the provider reads
+// it from.
+type entry struct {
+	key    string
+	value  reflect.Value
+	secret bool
+}
+
+type entries struct {
+	values []entry
+
+	// sequences holds the keys of the []Struct fields.
+	sequences map[string]struct{}
+}
+
+func collect(in interface{}, p provider.Provider, o *options) (*entries, error) {
+	es := entries{sequences: make(map[string]struct{})}
+
+	err := walker.Walk(in, es.walkFunc(provider.WrapFullyQualifiedProvider(p), o))
+
+	return &es, err
+}
+
+func (es *entries) walkFunc(fqp provider.FullyQualifiedProvider, o *options) walker.WalkFunc {
+	return func(f *walker.Field) error {
+		fv := reflectutil.IndirectedValue(f.Value).FieldByName(f.Field.Name)
+
+		if o.factory.Build(f.Field.Type) == nil {
+			return es.walkSubKeyField(fqp, o, f, fv)
+		}
+
+		ks := walker.BuildFieldKeys(fqp, f, o.ignoreMissingTag)
+
+		if len(ks) == 0 || isEmpty(fv) {
+			return walker.SkipStruct
+		}
+
+		es.values = append(
+			es.values,
+			entry{
+				key:    ks[0],
+				value:  reflectutil.IndirectedValue(fv),
+				secret: !o.includeSecrets && redact.IsSecret(f),
+			},
+		)
+
+		return walker.SkipStruct
+	}
+}
+
+func (es *entries) walkSubKeyField(fqp provider.FullyQualifiedProvider, o *options, f *walker.Field, fv reflect.Value) error {
+	isMap := reflectutil.SubKeyMapElem(f.Field.Type) != nil
+
+	if !isMap && reflectutil.SubKeySliceElem(f.Field.Type) == nil {
+		return nil
+	}
+
+	fv = reflectutil.IndirectedValue(fv)
+
+	if !fv.IsValid() || fv.Len() == 0 {
+		return walker.SkipStruct
+	}
+
+	var subKeys []string
+
+	if isMap {
+		for _, k := range fv.MapKeys() {
+			subKeys = append(subKeys, k.String())
+		}
+
+		sort.Strings(subKeys)
+	} else {
+		if ks := walker.BuildFieldKeys(fqp, f, o.ignoreMissingTag); len(ks) > 0 {
+			es.sequences[ks[0]] = struct{}{}
+		}
+
+		for i := 0; i < fv.Len(); i++ {
+			subKeys = append(subKeys, strconv.Itoa(i))
+		}
+	}
+
+	for i, k := range subKeys {
+		var ev reflect.Value
+
+		if isMap {
+			ev = fv.MapIndex(reflect.ValueOf(k).Convert(fv.Type().Key()))
+		} else {
+			ev = fv.Index(i)
+		}
+
+		if ev.Kind() == reflect.Ptr {
+			if ev.IsNil() {
+				continue
+			}
+		} else {
+			// Map values are not addressable.
+			cp := reflect.New(ev.Type())
+			cp.Elem().Set(ev)
+			ev = cp
+		}
+
+		if err := walker.Walk(
+			&walker.SubKeyPrefixed{Ancestor: f, SubKey: k, Value: ev.Interface()},
+			es.walkFunc(fqp, o),
+		); err != nil {
+			return err
+		}
+	}
+
+	return walker.SkipStruct
+}
+
+func isEmpty(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		return v.IsNil()
+	case reflect.Slice, reflect.Map:
+		return v.Len() == 0
+	}
+
+	return false
+}
+
+// Env returns the KEY=value assignments of the struct, as expected by
+// os/exec.Cmd.Env, formatted with the key rules of p.  Values are rendered
+// in the format expected by the setters.
+func Env(in interface{}, p provider.Provider, opts ...Option) ([]string, error) {
+	es, err := collect(in, p, newOptions(opts))
+
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]string, 0, len(es.values))
+
+	for _, e := range es.values {
+		res = append(res, formatKey(p, e.key)+"="+e.String())
+	}
+
+	return res, nil
+}
+
+func formatKey(p provider.Provider, k string) string {
+	if kf, ok := p.(provider.KeyFormatter); ok {
+		return kf.FormatKey(k)
+	}
+
+	return k
+}
+
+// Flags returns the command line arguments populating the struct with the
+// flags provider p.
+func Flags(in interface{}, p provider.Provider, opts ...Option) ([]string, error) {
+	es, err := collect(in, p, newOptions(opts))
+
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]string, 0, len(es.values))
+
+	for _, e := range es.values {
+		k := formatKey(p, e.key)
+
+		// The flags parser splits the --key=value form on every '=' and
+		// strips the quotes following it.
+		if v := e.String(); strings.ContainsAny(v, "=\"") {
+			res = append(res, k, v)
+		} else {
+			res = append(res, k+"="+v)
+		}
+	}
+
+	return res, nil
+}
+
+// JSON writes the struct as a JSON document read back by the json
+// provider.
+func JSON(w io.Writer, in interface{}, opts ...Option) error {
+	doc, err := document(in, &pjson.Provider{}, newOptions(opts))
+
+	if err != nil {
+		return err
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	enc.SetEscapeHTML(false)
+
+	return errors.Wrap(enc.Encode(doc), "encode")
+}
+
+// YAML writes the struct as a YAML document read back by the yaml
+// provider.
+func YAML(w io.Writer, in interface{}, opts ...Option) error {
+	doc, err := document(in, &pyaml.Provider{}, newOptions(opts))
+
+	if err != nil {
+		return err
+	}
+
+	enc := yaml.NewEncoder(w)
+	enc.SetIndent(2)
+
+	if err := enc.Encode(doc); err != nil {
+		return errors.Wrap(err, "encode")
+	}
+
+	return errors.Wrap(enc.Close(), "close")
+}
+
+func document(in interface{}, p provider.FullyQualifiedProvider, o *options) (interface{}, error) {
+	es, err := collect(in, p, o)
+
+	if err != nil {
+		return nil, err
+	}
+
+	root := make(map[string]interface{})
+
+	for _, e := range es.values {
+		if err := insert(root, strings.Split(e.key, "."), e.document()); err != nil {
+			return nil, errors.Wrapf(err, "key %q", e.key)
+		}
+	}
+
+	return toSequences(root, "", es.sequences), nil
+}
+
+func insert(node map[string]interface{}, path []string, v interface{}) error {
+	if len(path) == 1 {
+		if _, ok := node[path[0]]; ok {
+			return ErrConflictingKeys
+		}
+
+		node[path[0]] = v
+
+		return nil
+	}
+
+	next, ok := node[path[0]]
+
+	if !ok {
+		next = make(map[string]interface{})
+		node[path[0]] = next
+	}
+
+	child, ok := next.(map[string]interface{})
+
+	if !ok {
+		return ErrConflictingKeys
+	}
+
+	return insert(child, path[1:], v)
+}
+
+// toSequences turns the nodes holding the items of a []Struct field into
+// arrays.
+func toSequences(node interface{}, prefix string, sequences map[string]struct{}) interface{} {
+	m, ok := node.(map[string]interface{})
+
+	if !ok {
+		return node
+	}
+
+	for k, v := range m {
+		key := k
+
+		if prefix != "" {
+			key = prefix + "." + k
+		}
+
+		m[k] = toSequences(v, key, sequences)
+	}
+
+	if _, ok := sequences[prefix]; !ok {
+		return m
+	}
+
+	var (
+		res  []interface{}
+		idxs = make([]int, 0, len(m))
+	)
+
+	for k := range m {
+		idx, err := strconv.Atoi(k)
+
+		if err != nil {
+			return m
+		}
+
+		idxs = append(idxs, idx)
+	}
+
+	sort.Ints(idxs)
+
+	for _, idx := range idxs {
+		res = append(res, m[strconv.Itoa(idx)])
+	}
+
+	return res
+}
+
+// String renders the value in the format expected by the setters.
+func (e entry) String() string {
+	if e.secret {
+		return redact.Placeholder
+	}
+
+	return format(e.value)
+}
+
+// document renders the value as a node of a structured document, scalar
+// values keeping their type.
+func (e entry) document() interface{} {
+	if e.secret {
+		return redact.Placeholder
+	}
+
+	return native(e.value)
+}
+
+// textForm returns the value as a TextMarshaler or a Stringer, if it
+// implements one of them.
+func textForm(v reflect.Value) (interface{}, bool) {
+	if !v.CanInterface() {
+		return nil, false
+	}
+
+	vs := []interface{}{v.Interface()}
+
+	if v.CanAddr() {
+		vs = append(vs, v.Addr().Interface())
+	}
+
+	for _, vv := range vs {
+		switch vv.(type) {
+		case encoding.TextMarshaler, fmt.Stringer:
+			return vv, true
+		}
+	}
+
+	return nil, false
+}
+
+func format(v reflect.Value) string {
+	v = reflectutil.IndirectedValue(v)
+
+	if tv, ok := textForm(v); ok {
+		return text(tv)
+	}
+
+	switch v.Kind() {
+	case reflect.Slice, reflect.Array:
+		vs := make([]string, v.Len())
+
+		for i := range vs {
+			vs[i] = format(v.Index(i))
+		}
+
+		if hasQuote(vs...) {
+			return jsonLiteral(vs)
+		}
+
+		for i, vv := range vs {
+			vs[i] = quote(vv)
+		}
+
+		return strings.Join(vs, ",")
+	case reflect.Map:
+		kvs := make(map[string]string, v.Len())
+
+		for _, k := range v.MapKeys() {
+			kvs[format(k)] = format(v.MapIndex(k))
+		}
+
+		vs := make([]string, 0, len(kvs))
+
+		for k, vv := range kvs {
+			if hasQuote(k, vv) {
+				return jsonLiteral(kvs)
+			}
+
+			vs = append(vs, quote(k)+"="+quote(vv))
+		}
+
+		sort.Strings(vs)
+
+		return strings.Join(vs, ",")
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'g', -1, v.Type().Bits())
+	}
+
+	return fmt.Sprintf("%v", v.Interface())
+}
+
+func text(v interface{}) string {
+	switch vv := v.(type) {
+	case encoding.TextMarshaler:
+		if buf, err := vv.MarshalText(); err == nil {
+			return string(buf)
+		}
+	case fmt.Stringer:
+		return vv.String()
+	}
+
+	return fmt.Sprintf("%v", v)
+}
+
+// quote protects the separators of the slice and map values.
+func quote(s string) string {
+	if !strings.ContainsAny(s, ",=") {
+		return s
+	}
+
+	return "\"" + s + "\""
+}
+
+// hasQuote reports whether one of the values holds a quote, which quote
+// cannot protect. Such collections are written as JSON literals instead.
+func hasQuote(vs ...string) bool {
+	for _, v := range vs {
+		if strings.Contains(v, "\"") {
+			return true
+		}
+	}
+
+	return false
+}
+
+// jsonLiteral encodes a []string or a map[string]string, which cannot fail.
+func jsonLiteral(v interface{}) string {
+	buf, _ := json.Marshal(v)
+
+	return string(buf)
+}
+
+func native(v reflect.Value) interface{} {
+	v = reflectutil.IndirectedValue(v)
+
+	if tv, ok := textForm(v); ok {
+		return text(tv)
+	}
+
+	switch k := v.Kind(); {
+	case k == reflect.Bool:
+		return v.Bool()
+	case k == reflect.String:
+		return v.String()
+	case k >= reflect.Int && k <= reflect.Int64:
+		return v.Int()
+	case k >= reflect.Uint && k <= reflect.Uint64:
+		return v.Uint()
+	case k == reflect.Float32 || k == reflect.Float64:
+		return v.Float()
+	case k == reflect.Slice || k == reflect.Array:
+		res := make([]interface{}, v.Len())
+
+		for i := range res {
+			res[i] = native(v.Index(i))
+		}
+
+		return res
+	case k == reflect.Map:
+		res := make(map[string]interface{}, v.Len())
+
+		for _, mk := range v.MapKeys() {
+			res[format(mk)] = native(v.MapIndex(mk))
+		}
+
+		return res
+	}
+
+	return format(v)
+}
@@ -0,0 +1,196 @@
+package dump
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/upfluence/cfg"
+	"github.com/upfluence/cfg/provider"
+	"github.com/upfluence/cfg/provider/env"
+	"github.com/upfluence/cfg/provider/flags"
+	pjson "github.com/upfluence/cfg/provider/json"
+	pyaml "github.com/upfluence/cfg/provider/yaml"
+)
+
+type database struct {
+	Host string `env:"HOST" flag:"host" json:"host" yaml:"host"`
+	Port int    `env:"PORT" flag:"port" json:"port" yaml:"port"`
+}
+
+type config struct {
+	Name      string              `env:"NAME" flag:"name" json:"name" yaml:"name"`
+	Debug     bool                `env:"DEBUG" flag:"debug" json:"debug" yaml:"debug"`
+	Timeout   time.Duration       `env:"TIMEOUT" flag:"timeout" json:"timeout" yaml:"timeout"`
+	Ratio     float64             `env:"RATIO" flag:"ratio" json:"ratio" yaml:"ratio"`
+	Tags      []string            `env:"TAGS" flag:"tags" json:"tags" yaml:"tags"`
+	Labels    map[string]string   `env:"LABELS" flag:"labels" json:"labels" yaml:"labels"`
+	Token     string              `env:"TOKEN" flag:"token" json:"token" yaml:"token" secret:"true"`
+	Optional  *int                `env:"OPTIONAL" flag:"optional" json:"optional" yaml:"optional"`
+	Primary   database            `env:"PRIMARY" flag:"primary" json:"primary" yaml:"primary"`
+	Replicas  []database          `env:"REPLICAS" flag:"replicas" json:"replicas" yaml:"replicas"`
+	Databases map[string]database `env:"DATABASES" flag:"databases" json:"databases" yaml:"databases"`
+}
+
+func sample(dbKey string) config {
+	return config{
+		Name:    "app",
+		Debug:   true,
+		Timeout: 90 * time.Second,
+		Ratio:   0.5,
+		Tags:    []string{"a,b", "c"},
+		Labels:  map[string]string{"team": "core", "tier": "a=b"},
+		Token:   "hunter2",
+		Primary: database{Host: "h0", Port: 5432},
+		Replicas: []database{
+			{Host: "r0", Port: 1},
+			{Host: "r1", Port: 2},
+		},
+		Databases: map[string]database{dbKey: {Host: "a0", Port: 3}},
+	}
+}
+
+type mapEnvironment map[string]string
+
+func (e mapEnvironment) LookupEnv(k string) (string, bool) {
+	v, ok := e[k]
+	return v, ok
+}
+
+func (e mapEnvironment) Environ() []string {
+	var res []string
+
+	for k, v := range e {
+		res = append(res, k+"="+v)
+	}
+
+	return res
+}
+
+func populate(t *testing.T, p provider.Provider) config {
+	t.Helper()
+
+	var c config
+
+	require.NoError(t, cfg.NewConfigurator(p).Populate(context.Background(), &c))
+
+	return c
+}
+
+func TestEnv(t *testing.T) {
+	in := sample("ANALYTICS")
+
+	vs, err := Env(&in, env.NewProvider("app"), IncludeSecrets)
+	require.NoError(t, err)
+
+	assert.Contains(t, vs, "APP_TIMEOUT=1m30s")
+	assert.Contains(t, vs, "APP_REPLICAS_1_HOST=r1")
+	assert.Contains(t, vs, "APP_DATABASES_ANALYTICS_PORT=3")
+	assert.NotContains(t, vs, "APP_OPTIONAL=")
+
+	e := make(mapEnvironment)
+
+	for _, v := range vs {
+		k, v, _ := bytes.Cut([]byte(v), []byte("="))
+		e[string(k)] = string(v)
+	}
+
+	assert.Equal(t, in, populate(t, env.NewProviderFromEnvironment("app", e)))
+}
+
+func TestFlags(t *testing.T) {
+	in := sample("analytics")
+
+	args, err := Flags(&in, flags.NewDefaultProvider(), IncludeSecrets)
+	require.NoError(t, err)
+
+	assert.Contains(t, args, "--primary.port=5432")
+
+	assert.Equal(t, in, populate(t, flags.NewProvider(args)))
+}
+
+func TestJSON(t *testing.T) {
+	var (
+		in  = sample("analytics")
+		buf bytes.Buffer
+	)
+
+	require.NoError(t, JSON(&buf, &in, IncludeSecrets))
+	assert.Contains(t, buf.String(), `"replicas": [`)
+
+	assert.Equal(t, in, populate(t, pjson.NewProviderFromReader(&buf)))
+}
+
+func TestYAML(t *testing.T) {
+	var (
+		in  = sample("analytics")
+		buf bytes.Buffer
+	)
+
+	require.NoError(t, YAML(&buf, &in, IncludeSecrets))
+	assert.Contains(t, buf.String(), "replicas:\n  - host: r0\n")
+
+	assert.Equal(t, in, populate(t, pyaml.NewProviderFromReader(&buf)))
+}
+
+func TestSecretsRedacted(t *testing.T) {
+	in := sample("ANALYTICS")
+
+	vs, err := Env(&in, env.NewDefaultProvider())
+	require.NoError(t, err)
+
+	assert.Contains(t, vs, "TOKEN=<redacted>")
+	assert.NotContains(t, vs, "TOKEN=hunter2")
+}
+
+func TestConflictingKeys(t *testing.T) {
+	var buf bytes.Buffer
+
+	err := JSON(
+		&buf,
+		&struct {
+			DB   string `json:"db"`
+			Host string `json:"db.host"`
+		}{DB: "x", Host: "y"},
+	)
+
+	assert.ErrorIs(t, err, ErrConflictingKeys)
+}
+
+func quotedSample(dbKey string) config {
+	c := sample(dbKey)
+
+	c.Tags = []string{`say "hi"`, "a,b"}
+	c.Labels = map[string]string{"team": `"core"`, "tier": "a=b"}
+
+	return c
+}
+
+func TestQuotedValues(t *testing.T) {
+	in := quotedSample("ANALYTICS")
+
+	vs, err := Env(&in, env.NewProvider("app"), IncludeSecrets)
+	require.NoError(t, err)
+
+	assert.Contains(t, vs, `APP_TAGS=["say \"hi\"","a,b"]`)
+
+	e := make(mapEnvironment)
+
+	for _, v := range vs {
+		k, v, _ := bytes.Cut([]byte(v), []byte("="))
+		e[string(k)] = string(v)
+	}
+
+	assert.Equal(t, in, populate(t, env.NewProviderFromEnvironment("app", e)))
+
+	in = quotedSample("analytics")
+
+	args, err := Flags(&in, flags.NewDefaultProvider(), IncludeSecrets)
+	require.NoError(t, err)
+
+	assert.Equal(t, in, populate(t, flags.NewProvider(args)))
+}
@@ -13,10 +13,22 @@ var (
 	ErrShouldBePtr = errors.New("x/parser: input should be a pointer")
 
 	WithDateFormat = setter.WithDateFormat
+	WithTypeParser = setter.WithTypeParser
+	WithKindParser = setter.WithKindParser
 )
 
 type Option = setter.FactoryOption
 
+// ParseFunc parses the value of a type registered with WithTypeParser or
+// WithKindParser, t being the type of the target.
+type ParseFunc = setter.ParseFunc
+
+// WithParser registers fn as the parser of the values of type T, name
+// being the type displayed in the help messages.
+func WithParser[T any](name string, fn func(string) (T, error)) Option {
+	return setter.WithParser(name, fn)
+}
+
 type Parser struct {
 	sf setter.Factory
 }
@@ -1,10 +1,19 @@
 package parser
 
 import (
+	"io/fs"
+	"net"
+	"net/netip"
+	"net/url"
+	"reflect"
+	"regexp"
+	"strconv"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
+
+	"github.com/upfluence/cfg/internal/setter"
 )
 
 func TestParse(t *testing.T) {
@@ -30,3 +39,246 @@ func TestParseUnsupportedType(t *testing.T) {
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "unsupported type")
 }
+
+func TestParseRegisteredParsers(t *testing.T) {
+	opts := []Option{
+		WithParser("url", url.Parse),
+		WithTypeParser(
+			reflect.TypeFor[int](),
+			"hex",
+			func(v string, _ reflect.Type) (interface{}, error) {
+				return strconv.ParseInt(v, 16, 64)
+			},
+		),
+	}
+
+	var u url.URL
+
+	err := Parse("https://example.com", &u, opts...)
+	assert.NoError(t, err)
+	assert.Equal(t, "example.com", u.Host)
+
+	var us []*url.URL
+
+	err = Parse("http://a,http://b", &us, opts...)
+	assert.NoError(t, err)
+	assert.Len(t, us, 2)
+	assert.Equal(t, "b", us[1].Host)
+
+	var i int
+
+	err = Parse("ff", &i, opts...)
+	assert.NoError(t, err)
+	assert.Equal(t, 255, i)
+
+	err = Parse("%zz", &u, opts...)
+	assert.Error(t, err)
+}
+
+func TestParseKindParser(t *testing.T) {
+	type level uint8
+
+	opt := WithKindParser(
+		reflect.Uint8,
+		func(t reflect.Type) bool { return t.Name() == "level" },
+		"level",
+		func(v string, _ reflect.Type) (interface{}, error) {
+			return uint8(len(v)), nil
+		},
+	)
+
+	var l level
+
+	err := Parse("high", &l, opt)
+	assert.NoError(t, err)
+	assert.Equal(t, level(4), l)
+
+	var b uint8
+
+	err = Parse("12", &b, opt)
+	assert.NoError(t, err)
+	assert.Equal(t, uint8(12), b)
+}
+
+func TestParseStdlibTypes(t *testing.T) {
+	for _, tt := range []struct {
+		name   string
+		in     string
+		target func() interface{}
+		want   interface{}
+		errFn  assert.ErrorAssertionFunc
+	}{
+		{
+			name:   "url",
+			in:     "https://example.com/path",
+			target: func() interface{} { return new(*url.URL) },
+			want:   &url.URL{Scheme: "https", Host: "example.com", Path: "/path"},
+		},
+		{
+			name:   "ip",
+			in:     "10.0.0.1",
+			target: func() interface{} { return new(net.IP) },
+			want:   net.ParseIP("10.0.0.1"),
+		},
+		{
+			name:   "invalid ip",
+			in:     "10.0.0",
+			target: func() interface{} { return new(net.IP) },
+			errFn:  assert.Error,
+		},
+		{
+			name:   "cidr slice",
+			in:     "10.0.0.0/8,192.168.1.0/24",
+			target: func() interface{} { return new([]net.IPNet) },
+			want: []net.IPNet{
+				{IP: net.IPv4(10, 0, 0, 0).To4(), Mask: net.CIDRMask(8, 32)},
+				{IP: net.IPv4(192, 168, 1, 0).To4(), Mask: net.CIDRMask(24, 32)},
+			},
+		},
+		{
+			name:   "netip",
+			in:     "[::1]:8080",
+			target: func() interface{} { return new(netip.AddrPort) },
+			want:   netip.MustParseAddrPort("[::1]:8080"),
+		},
+		{
+			name:   "netip prefix map",
+			in:     "lan=192.168.0.0/16",
+			target: func() interface{} { return new(map[string]netip.Prefix) },
+			want:   map[string]netip.Prefix{"lan": netip.MustParsePrefix("192.168.0.0/16")},
+		},
+		{
+			name:   "location",
+			in:     "UTC",
+			target: func() interface{} { return new(*time.Location) },
+			want:   time.UTC,
+		},
+		{
+			name:   "octal file mode",
+			in:     "0640",
+			target: func() interface{} { return new(fs.FileMode) },
+			want:   fs.FileMode(0640),
+		},
+		{
+			name:   "symbolic file mode",
+			in:     "-rwxr-x---",
+			target: func() interface{} { return new(fs.FileMode) },
+			want:   fs.FileMode(0750),
+		},
+		{
+			name:   "invalid file mode",
+			in:     "0999",
+			target: func() interface{} { return new(fs.FileMode) },
+			errFn:  assert.Error,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			target := tt.target()
+
+			err := Parse(tt.in, target)
+
+			if tt.errFn != nil {
+				tt.errFn(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, reflect.ValueOf(target).Elem().Interface())
+		})
+	}
+}
+
+func TestParseRegexp(t *testing.T) {
+	var re *regexp.Regexp
+
+	err := Parse("^a+b$", &re)
+	assert.NoError(t, err)
+	assert.True(t, re.MatchString("aab"))
+
+	err = Parse("(", &re)
+	assert.Error(t, err)
+}
+
+func TestParseUnits(t *testing.T) {
+	for _, tt := range []struct {
+		in     string
+		target interface{}
+		want   interface{}
+		err    bool
+	}{
+		{in: "512MiB", target: new(setter.ByteSize), want: 512 * setter.MiB},
+		{in: "1.5 GB", target: new(setter.ByteSize), want: setter.ByteSize(1500000000)},
+		{in: "2kib", target: new(setter.ByteSize), want: 2 * setter.KiB},
+		{in: "1024", target: new(setter.ByteSize), want: setter.ByteSize(1024)},
+		{in: "1.5B", target: new(setter.ByteSize), err: true},
+		{in: "10M", target: new(setter.ByteSize), err: true},
+		{in: "10k", target: new(setter.Quantity), want: setter.Quantity(10000)},
+		{in: "1.5M", target: new(setter.Quantity), want: setter.Quantity(1500000)},
+		{in: "10x", target: new(setter.Quantity), err: true},
+		{in: "10k/s", target: new(setter.Rate), want: setter.Rate(10000)},
+		{in: "120/min", target: new(setter.Rate), want: setter.Rate(2)},
+		{in: "5/100ms", target: new(setter.Rate), want: setter.Rate(50)},
+		{in: "10", target: new(setter.Rate), err: true},
+		{in: "12.5%", target: new(setter.Percent), want: setter.Percent(0.125)},
+		{in: "0.5", target: new(setter.Percent), want: setter.Percent(0.5)},
+		{
+			in:     "1KiB,2MB",
+			target: new([]setter.ByteSize),
+			want:   []setter.ByteSize{setter.KiB, 2 * setter.MB},
+		},
+		{
+			in:     "api=10/s",
+			target: new(map[string]*setter.Rate),
+			want:   map[string]*setter.Rate{"api": func() *setter.Rate { r := setter.Rate(10); return &r }()},
+		},
+	} {
+		t.Run(tt.in, func(t *testing.T) {
+			err := Parse(tt.in, tt.target)
+
+			if tt.err {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, reflect.ValueOf(tt.target).Elem().Interface())
+		})
+	}
+}
+
+func TestParseJSONLiterals(t *testing.T) {
+	for _, tt := range []struct {
+		in     string
+		target interface{}
+		want   interface{}
+	}{
+		{
+			in:     `["a,b", "c"]`,
+			target: new([]string),
+			want:   []string{"a,b", "c"},
+		},
+		{in: `[1, 2, 3]`, target: new([]int), want: []int{1, 2, 3}},
+		{in: `[]`, target: new([]string), want: []string{}},
+		{in: `[a]`, target: new([]string), want: []string{"[a]"}},
+		{
+			in:     `{"a": ["b", "c,d"], "e": "f,g"}`,
+			target: new(map[string][]string),
+			want:   map[string][]string{"a": {"b", "c,d"}, "e": {"f", "g"}},
+		},
+		{
+			in:     `{"a=b": "c,d"}`,
+			target: new(map[string]string),
+			want:   map[string]string{"a=b": "c,d"},
+		},
+		{
+			in:     `{"1": true}`,
+			target: new(map[int]bool),
+			want:   map[int]bool{1: true},
+		},
+	} {
+		t.Run(tt.in, func(t *testing.T) {
+			assert.NoError(t, Parse(tt.in, tt.target))
+			assert.Equal(t, tt.want, reflect.ValueOf(tt.target).Elem().Interface())
+		})
+	}
+}